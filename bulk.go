@@ -0,0 +1,255 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBulkConcurrency is the number of workers of the bulk operations.
+	DefaultBulkConcurrency = 3
+
+	bulkMaxRetries = 3
+)
+
+// PropertiesUpdate is the item of BulkUpdateProperties.
+type PropertiesUpdate struct {
+	PageID     string
+	Properties map[string]*PropertyData
+}
+
+// BlockAppend is the item of BulkAppend.
+type BlockAppend struct {
+	BlockID  string
+	Children []*Block
+}
+
+// BulkItemError is the error of an item of the bulk operation.
+type BulkItemError struct {
+	// Index is the index of the item in the arguments.
+	Index int
+	// ID is the page ID or the block ID of the item.
+	ID  string
+	Err error
+}
+
+func (e *BulkItemError) Error() string {
+	return fmt.Sprintf("%s: %v", e.ID, e.Err)
+}
+
+func (e *BulkItemError) Unwrap() error {
+	return e.Err
+}
+
+// BulkError aggregates the errors of the bulk operation.
+// Errors is ordered by Index.
+type BulkError struct {
+	Errors []*BulkItemError
+}
+
+func (e *BulkError) Error() string {
+	if len(e.Errors) == 1 {
+		return fmt.Sprintf("notion: 1 item failed: %v", e.Errors[0])
+	}
+
+	msgs := make([]string, len(e.Errors))
+	for i, v := range e.Errors {
+		msgs[i] = v.Error()
+	}
+	return fmt.Sprintf("notion: %d items failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, v := range e.Errors {
+		errs[i] = v
+	}
+	return errs
+}
+
+// BulkProgressFunc is called each time an item is processed.
+// err is nil if the item succeeded. BulkProgressFunc is never called concurrently.
+type BulkProgressFunc func(done, total int, err error)
+
+type bulkOptions struct {
+	concurrency int
+	rateLimit   float64
+	burst       int
+	progress    BulkProgressFunc
+	stopOnError bool
+	retryWait   time.Duration
+}
+
+type BulkOpt func(*bulkOptions)
+
+// WithBulkConcurrency specifies the number of workers.
+// The default is DefaultBulkConcurrency.
+func WithBulkConcurrency(n int) BulkOpt {
+	return func(o *bulkOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithBulkRateLimit specifies the number of requests per second and the burst size.
+// The default is DefaultRateLimit. If rps is 0, the requests are not limited.
+func WithBulkRateLimit(rps float64, burst int) BulkOpt {
+	return func(o *bulkOptions) {
+		o.rateLimit = rps
+		o.burst = burst
+	}
+}
+
+// WithBulkProgress specifies the callback which reports the progress.
+func WithBulkProgress(f BulkProgressFunc) BulkOpt {
+	return func(o *bulkOptions) {
+		o.progress = f
+	}
+}
+
+// WithBulkStopOnError stops the bulk operation after the first failure.
+// The items which are not processed yet are skipped and not reported as errors.
+func WithBulkStopOnError() BulkOpt {
+	return func(o *bulkOptions) {
+		o.stopOnError = true
+	}
+}
+
+// BulkUpdateProperties updates properties of the pages concurrently.
+// The returned pages are in the same order as updates. The page of the failed item is nil.
+// If some items failed, BulkUpdateProperties returns *BulkError.
+func (c *Client) BulkUpdateProperties(ctx context.Context, updates []*PropertiesUpdate, opts ...BulkOpt) ([]*Page, error) {
+	pages := make([]*Page, len(updates))
+	err := runBulk(ctx, len(updates), opts, func(i int) string { return updates[i].PageID }, func(ctx context.Context, i int) error {
+		page, err := c.UpdateProperties(ctx, updates[i].PageID, updates[i].Properties)
+		if err != nil {
+			return err
+		}
+		pages[i] = page
+		return nil
+	})
+	return pages, err
+}
+
+// BulkArchive archives the pages concurrently.
+// If some items failed, BulkArchive returns *BulkError.
+func (c *Client) BulkArchive(ctx context.Context, pageIDs []string, opts ...BulkOpt) error {
+	return runBulk(ctx, len(pageIDs), opts, func(i int) string { return pageIDs[i] }, func(ctx context.Context, i int) error {
+		_, err := c.ArchivePage(ctx, pageIDs[i])
+		return err
+	})
+}
+
+// BulkAppend appends children to the blocks concurrently.
+// The returned blocks are in the same order as appends. The blocks of the failed item is nil.
+// If some items failed, BulkAppend returns *BulkError.
+func (c *Client) BulkAppend(ctx context.Context, appends []*BlockAppend, opts ...BulkOpt) ([][]*Block, error) {
+	blocks := make([][]*Block, len(appends))
+	err := runBulk(ctx, len(appends), opts, func(i int) string { return appends[i].BlockID }, func(ctx context.Context, i int) error {
+		b, err := c.AppendBlock(ctx, appends[i].BlockID, appends[i].Children)
+		if err != nil {
+			return err
+		}
+		blocks[i] = b
+		return nil
+	})
+	return blocks, err
+}
+
+// runBulk calls fn for each index with the worker pool.
+func runBulk(ctx context.Context, total int, opts []BulkOpt, id func(int) string, fn func(context.Context, int) error) error {
+	o := &bulkOptions{concurrency: DefaultBulkConcurrency, rateLimit: DefaultRateLimit, burst: DefaultBulkConcurrency, retryWait: time.Second}
+	for _, v := range opts {
+		v(o)
+	}
+	if o.concurrency < 1 {
+		o.concurrency = 1
+	}
+	limiter := newRateLimiter(o.rateLimit, o.burst, time.Now)
+
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	var mu sync.Mutex
+	var errs []*BulkItemError
+	done := 0
+	report := func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		done++
+		if err != nil {
+			errs = append(errs, &BulkItemError{Index: i, ID: id(i), Err: err})
+			if o.stopOnError {
+				stopOnce.Do(func() { close(stop) })
+			}
+		}
+		if o.progress != nil {
+			o.progress(done, total, err)
+		}
+	}
+
+	items := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < o.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range items {
+				report(i, o.do(ctx, limiter, i, fn))
+			}
+		}()
+	}
+Items:
+	for i := 0; i < total; i++ {
+		select {
+		case <-ctx.Done():
+			break Items
+		case <-stop:
+			break Items
+		case items <- i:
+		}
+	}
+	close(items)
+	wg.Wait()
+
+	if len(errs) == 0 {
+		// The parent context is cancelled before processing all items.
+		if done < total {
+			return ctx.Err()
+		}
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Index < errs[j].Index })
+	return &BulkError{Errors: errs}
+}
+
+// do calls fn with retrying while the request is rate limited.
+func (o *bulkOptions) do(ctx context.Context, limiter *rateLimiter, i int, fn func(context.Context, int) error) error {
+	for n := 0; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		err := fn(ctx, i)
+		var apiErr *Error
+		if n >= bulkMaxRetries || !errors.As(err, &apiErr) || apiErr.Status != http.StatusTooManyRequests {
+			return err
+		}
+
+		t := time.NewTimer(o.retryWait << n)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
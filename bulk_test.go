@@ -0,0 +1,195 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkUpdateProperties(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/patch-page.json")
+	require.NoError(t, err)
+	newTransport := func() *httpmock.MockTransport {
+		rt := httpmock.NewMockTransport()
+		rt.RegisterRegexpResponder(
+			http.MethodPatch,
+			regexp.MustCompile(`/v1/pages/[a-z0-9-]+$`),
+			func(req *http.Request) (*http.Response, error) {
+				if strings.HasSuffix(req.URL.Path, "-missing") {
+					return httpmock.NewStringResponse(http.StatusNotFound, `{"object":"error","status":404,"code":"object_not_found","message":"Could not find page"}`), nil
+				}
+				return httpmock.NewStringResponse(http.StatusOK, string(res)), nil
+			},
+		)
+		return rt
+	}
+	updates := make([]*PropertiesUpdate, 10)
+	for i := range updates {
+		id := fmt.Sprintf("page-%d", i)
+		if i == 3 || i == 7 {
+			id += "-missing"
+		}
+		updates[i] = &PropertiesUpdate{PageID: id, Properties: map[string]*PropertyData{"Test8": {Type: PropertyTypeCheckbox, Checkbox: true}}}
+	}
+
+	t.Run("CollectErrors", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := New(&http.Client{Transport: newTransport()}, "https://example.com")
+		require.NoError(t, err)
+
+		var progress []int
+		var failed int
+		pages, err := client.BulkUpdateProperties(context.Background(), updates,
+			WithBulkConcurrency(4),
+			WithBulkRateLimit(0, 0),
+			WithBulkProgress(func(done, total int, err error) {
+				assert.Equal(t, 10, total)
+				progress = append(progress, done)
+				if err != nil {
+					failed++
+				}
+			}),
+		)
+		require.Error(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, progress)
+		assert.Equal(t, 2, failed)
+
+		var bulkErr *BulkError
+		require.ErrorAs(t, err, &bulkErr)
+		require.Len(t, bulkErr.Errors, 2)
+		assert.Equal(t, 3, bulkErr.Errors[0].Index)
+		assert.Equal(t, "page-3-missing", bulkErr.Errors[0].ID)
+		assert.Equal(t, 7, bulkErr.Errors[1].Index)
+		var apiErr *Error
+		assert.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.Status)
+
+		require.Len(t, pages, 10)
+		for i, v := range pages {
+			if i == 3 || i == 7 {
+				assert.Nil(t, v)
+			} else {
+				assert.NotNil(t, v)
+			}
+		}
+	})
+
+	t.Run("StopOnError", func(t *testing.T) {
+		t.Parallel()
+
+		rt := newTransport()
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		pages, err := client.BulkUpdateProperties(context.Background(), updates,
+			WithBulkConcurrency(1),
+			WithBulkRateLimit(0, 0),
+			WithBulkStopOnError(),
+		)
+		var bulkErr *BulkError
+		require.ErrorAs(t, err, &bulkErr)
+		require.Len(t, bulkErr.Errors, 1)
+		assert.Equal(t, 3, bulkErr.Errors[0].Index)
+		assert.LessOrEqual(t, rt.GetTotalCallCount(), 5)
+		for _, v := range pages[5:] {
+			assert.Nil(t, v)
+		}
+	})
+}
+
+func TestBulkArchive(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/patch-page.json")
+	require.NoError(t, err)
+	var mu sync.Mutex
+	archived := make(map[string]bool)
+	var inFlight, maxInFlight int32
+	var calls int32
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodPatch,
+		regexp.MustCompile(`/v1/pages/[a-z0-9-]+$`),
+		func(req *http.Request) (*http.Response, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			// The first request is rate limited.
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return httpmock.NewStringResponse(http.StatusTooManyRequests, `{"object":"error","status":429,"code":"rate_limited","message":"Rate limited"}`), nil
+			}
+			time.Sleep(time.Millisecond)
+
+			body := make(map[string]interface{})
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			mu.Lock()
+			archived[req.URL.Path] = body["archived"] == true
+			mu.Unlock()
+			return httpmock.NewStringResponse(http.StatusOK, string(res)), nil
+		},
+	)
+
+	client, err := New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+	pageIDs := make([]string, 8)
+	for i := range pageIDs {
+		pageIDs[i] = fmt.Sprintf("page-%d", i)
+	}
+	err = client.BulkArchive(context.Background(), pageIDs,
+		WithBulkConcurrency(2),
+		WithBulkRateLimit(1000, 2),
+		func(o *bulkOptions) { o.retryWait = time.Millisecond },
+	)
+	require.NoError(t, err)
+	assert.Len(t, archived, 8)
+	for _, v := range archived {
+		assert.True(t, v)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+	assert.Equal(t, int32(9), atomic.LoadInt32(&calls))
+}
+
+func TestBulkAppend(t *testing.T) {
+	t.Parallel()
+
+	client, err := New(&http.Client{Transport: mockTransport(t, http.MethodPatch, `/v1/blocks/[a-z0-9-]+/children$`, http.StatusOK, "./testdata/patch-block-children.json")}, "https://example.com")
+	require.NoError(t, err)
+
+	appends := []*BlockAppend{
+		{BlockID: "block-1", Children: []*Block{{Type: BlockTypeDivider, Divider: &struct{}{}}}},
+		{BlockID: "block-2", Children: []*Block{{Type: BlockTypeDivider, Divider: &struct{}{}}}},
+	}
+	blocks, err := client.BulkAppend(context.Background(), appends, WithBulkRateLimit(0, 0))
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.NotEmpty(t, blocks[0])
+	assert.NotEmpty(t, blocks[1])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.BulkAppend(ctx, appends, WithBulkRateLimit(0, 0))
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -4,26 +4,59 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"path"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	BaseURL   = "https://api.notion.com/v1"
 	UserAgent = "go.f110.dev/notion-api v3"
 
-	notionVersion = "2022-06-28"
+	// Version20220628 is the default version of Notion API.
+	Version20220628 = "2022-06-28"
+	// Version20250903 is the version which introduces data sources.
+	// Pages in a database belong to a data source since this version.
+	Version20250903 = "2025-09-03"
+
+	notionVersion = Version20220628
+
+	fileUploadSinglePartMaxSize = 20 * 1024 * 1024
+	fileUploadPartSize          = 10 * 1024 * 1024
+
+	// maxAppendBlocks is the maximum number of blocks which can be appended by one request.
+	maxAppendBlocks = 100
+
+	// fileURLExpiryMargin is the margin of the expiry time of the file URL.
+	// The URL which will be expired within the margin is regarded as expired.
+	fileURLExpiryMargin = time.Minute
 )
 
 type Client struct {
 	httpClient *http.Client
 	baseURL    *url.URL
+	version    string
+}
+
+type ClientOpt func(*Client)
+
+// WithVersion specifies the version of Notion API.
+// The default version is 2022-06-28.
+func WithVersion(v string) ClientOpt {
+	return func(c *Client) {
+		c.version = v
+	}
 }
 
-func New(c *http.Client, baseURL string) (*Client, error) {
+func New(c *http.Client, baseURL string, opts ...ClientOpt) (*Client, error) {
 	u, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed parse base URL: %v", err)
@@ -32,7 +65,16 @@ func New(c *http.Client, baseURL string) (*Client, error) {
 		u.Path = "/v1"
 	}
 
-	return &Client{httpClient: c, baseURL: u}, nil
+	client := &Client{httpClient: c, baseURL: u, version: notionVersion}
+	for _, v := range opts {
+		v(client)
+	}
+	return client, nil
+}
+
+// Version returns the version of Notion API which is used by the client.
+func (c *Client) Version() string {
+	return c.version
 }
 
 // GetUser can get a user.
@@ -173,56 +215,188 @@ func (c *Client) UpdateDatabase(ctx context.Context, db *Database) (*Database, e
 }
 
 // GetPages can get all pages which belongs to the database.
+// GetPages is not available since 2025-09-03. Use QueryDataSource instead.
 // ref: https://developers.notion.com/reference/post-database-query
 func (c *Client) GetPages(ctx context.Context, databaseID string, filter *Filter, sorts []*Sort) ([]*Page, error) {
-	data := &struct {
-		Filter      *Filter `json:"filter,omitempty"`
-		Sorts       []*Sort `json:"sorts,omitempty"`
-		PageSize    int     `json:"page_size"`
-		StartCursor string  `json:"start_cursor,omitempty"`
-	}{
-		Filter: filter, Sorts: sorts,
+	if c.supportsDataSource() {
+		return nil, c.removedVersionError(Version20250903)
 	}
 
-	pages := make([]*Page, 0)
-	for {
-		buf := new(bytes.Buffer)
-		if err := json.NewEncoder(buf).Encode(data); err != nil {
-			return nil, err
-		}
+	pages, err := c.queryPages(ctx, fmt.Sprintf("/databases/%s/query", databaseID), filter, sorts, "")
+	if err != nil {
+		return nil, unwrapPaginationError(err)
+	}
 
-		req, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/databases/%s/query", databaseID), nil, buf)
-		if err != nil {
-			return nil, err
-		}
-		res, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
+	return pages, nil
+}
 
-		switch res.StatusCode {
-		case http.StatusOK:
-		default:
-			return nil, c.decodeError(res)
-		}
+// GetPagesFrom is the same as GetPages except that the pagination starts from startCursor.
+// If the pagination is interrupted (e.g. the context is cancelled),
+// GetPagesFrom returns the pages which are collected so far with *PaginationError.
+// The query can be resumed by PaginationError.NextCursor.
+func (c *Client) GetPagesFrom(ctx context.Context, databaseID string, filter *Filter, sorts []*Sort, startCursor string) ([]*Page, error) {
+	if c.supportsDataSource() {
+		return nil, c.removedVersionError(Version20250903)
+	}
 
-		obj := &PageList{}
-		if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
-			res.Body.Close()
-			return nil, fmt.Errorf("failed parse a response: %v", err)
-		}
-		pages = append(pages, obj.Results...)
-		res.Body.Close()
+	return c.queryPages(ctx, fmt.Sprintf("/databases/%s/query", databaseID), filter, sorts, startCursor)
+}
 
-		if !obj.HasMore {
-			break
-		}
-		data.StartCursor = obj.NextCursor
+// ErrMultipleDataSources is returned when the data source of the database can't be decided.
+var ErrMultipleDataSources = errors.New("notion: the database has multiple data sources")
+
+// ResolveDataSourceID returns the ID of the data source of the database.
+// Since 2025-09-03, the pages belong to the data source instead of the database.
+// If the database has multiple data sources, ResolveDataSourceID returns ErrMultipleDataSources.
+// In that case, specify the data source explicitly (e.g. QueryDataSource).
+func (c *Client) ResolveDataSourceID(ctx context.Context, databaseID string) (string, error) {
+	if !c.supportsDataSource() {
+		return "", c.unsupportedVersionError(Version20250903)
+	}
+
+	db, err := c.GetDatabase(ctx, databaseID)
+	if err != nil {
+		return "", err
+	}
+	return dataSourceIDOf(db)
+}
+
+func dataSourceIDOf(db *Database) (string, error) {
+	switch len(db.DataSources) {
+	case 0:
+		return "", fmt.Errorf("notion: the database %s has no data source", db.ID)
+	case 1:
+		return db.DataSources[0].ID, nil
+	default:
+		return "", fmt.Errorf("%w: %s has %d data sources. Use QueryDataSource with the data source", ErrMultipleDataSources, db.ID, len(db.DataSources))
+	}
+}
+
+// QueryDatabase can get all pages which belongs to the database regardless of the version of the client.
+// Since 2025-09-03, QueryDatabase resolves the data source of the database on each call and queries the data source.
+// If the database has multiple data sources, QueryDatabase returns ErrMultipleDataSources.
+func (c *Client) QueryDatabase(ctx context.Context, databaseID string, filter *Filter, sorts []*Sort) ([]*Page, error) {
+	if !c.supportsDataSource() {
+		return c.GetPages(ctx, databaseID, filter, sorts)
+	}
+
+	dataSourceID, err := c.ResolveDataSourceID(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	return c.QueryDataSource(ctx, dataSourceID, filter, sorts)
+}
+
+// GetDataSource can get a data source.
+// ref: https://developers.notion.com/reference/retrieve-a-data-source
+func (c *Client) GetDataSource(ctx context.Context, dataSourceID string) (*DataSource, error) {
+	if !c.supportsDataSource() {
+		return nil, c.unsupportedVersionError(Version20250903)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/data_sources/%s", dataSourceID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	default:
+		return nil, c.decodeError(res)
+	}
+
+	obj := &DataSource{}
+	if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+		return nil, fmt.Errorf("failed parse a response: %v", err)
+	}
+	if err := obj.decode(); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// QueryDataSource can get all pages which belongs to the data source.
+// ref: https://developers.notion.com/reference/query-a-data-source
+func (c *Client) QueryDataSource(ctx context.Context, dataSourceID string, filter *Filter, sorts []*Sort) ([]*Page, error) {
+	if !c.supportsDataSource() {
+		return nil, c.unsupportedVersionError(Version20250903)
+	}
+
+	pages, err := c.queryPages(ctx, fmt.Sprintf("/data_sources/%s/query", dataSourceID), filter, sorts, "")
+	if err != nil {
+		return nil, unwrapPaginationError(err)
 	}
 
 	return pages, nil
 }
 
+// QueryDataSourceFrom is the same as QueryDataSource except that the pagination starts from startCursor.
+// If the pagination is interrupted, QueryDataSourceFrom returns the pages which are collected so far with *PaginationError.
+func (c *Client) QueryDataSourceFrom(ctx context.Context, dataSourceID string, filter *Filter, sorts []*Sort, startCursor string) ([]*Page, error) {
+	if !c.supportsDataSource() {
+		return nil, c.unsupportedVersionError(Version20250903)
+	}
+
+	return c.queryPages(ctx, fmt.Sprintf("/data_sources/%s/query", dataSourceID), filter, sorts, startCursor)
+}
+
+// UpdateDataSource can update the title and the properties of a data source.
+// ref: https://developers.notion.com/reference/update-a-data-source
+func (c *Client) UpdateDataSource(ctx context.Context, ds *DataSource) (*DataSource, error) {
+	if !c.supportsDataSource() {
+		return nil, c.unsupportedVersionError(Version20250903)
+	}
+
+	body := struct {
+		Title      []*RichTextObject            `json:"title,omitempty"`
+		Properties map[string]*PropertyMetadata `json:"properties"`
+	}{
+		Title:      ds.Title,
+		Properties: ds.Properties,
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return nil, fmt.Errorf("notion: failed to encode request body: %v", err)
+	}
+	req, err := c.newRequest(ctx, http.MethodPatch, fmt.Sprintf("/data_sources/%s", ds.ID), nil, buf)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	default:
+		return nil, c.decodeError(res)
+	}
+
+	obj := &DataSource{}
+	if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+		return nil, fmt.Errorf("notion: failed parse a response: %v", err)
+	}
+	if err := obj.decode(); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// queryPages returns the pages which are collected so far with *PaginationError if the pagination is interrupted.
+func (c *Client) queryPages(ctx context.Context, apiPath string, filter *Filter, sorts []*Sort, startCursor string) ([]*Page, error) {
+	return collect(newIterator(ctx, startCursor, c.queryPagesFetcher(apiPath, filter, sorts), decodePage))
+}
+
 // GetPage can get single page.
 // ref: https://developers.notion.com/reference/get-page
 func (c *Client) GetPage(ctx context.Context, pageID string) (*Page, error) {
@@ -348,6 +522,25 @@ func (c *Client) GetBlock(ctx context.Context, blockID string) (*Block, error) {
 	return obj, nil
 }
 
+// RefreshPage returns the page which has the fresh URLs of the files hosted by Notion.
+// The URLs of the files hosted by Notion are expired in an hour.
+// If no URL is expired, RefreshPage returns page as is without the request.
+func (c *Client) RefreshPage(ctx context.Context, page *Page) (*Page, error) {
+	if !page.HasExpiredFile(time.Now().Add(fileURLExpiryMargin)) {
+		return page, nil
+	}
+	return c.GetPage(ctx, page.ID)
+}
+
+// RefreshBlock returns the block which has the fresh URL of the file hosted by Notion.
+// If the URL is not expired, RefreshBlock returns block as is without the request.
+func (c *Client) RefreshBlock(ctx context.Context, block *Block) (*Block, error) {
+	if !block.HasExpiredFile(time.Now().Add(fileURLExpiryMargin)) {
+		return block, nil
+	}
+	return c.GetBlock(ctx, block.ID)
+}
+
 // UpdateBlock can update a block
 // ref: https://developers.notion.com/reference/update-a-block
 func (c *Client) UpdateBlock(ctx context.Context, block *Block) (*Block, error) {
@@ -402,6 +595,26 @@ func (c *Client) DeleteBlock(ctx context.Context, blockID string) error {
 // CreatePage can create a page.
 // ref: https://developers.notion.com/reference/post-page
 func (c *Client) CreatePage(ctx context.Context, page *Page) (*Page, error) {
+	if page.Template != nil && !c.supportsDataSource() {
+		return nil, c.unsupportedVersionError(Version20250903)
+	}
+	if c.supportsDataSource() && page.Parent != nil {
+		// The parent of the caller is not rewritten.
+		parent := *page.Parent
+		parent.resolveDataSource()
+		if parent.DatabaseID != "" && parent.DataSourceID == "" && (parent.Database == nil || len(parent.Database.DataSources) == 0) {
+			// The data sources of the database are unknown (e.g. only the ID of the database is specified).
+			id, err := c.ResolveDataSourceID(ctx, parent.DatabaseID)
+			if err != nil {
+				return nil, err
+			}
+			parent.Type, parent.DataSourceID, parent.DatabaseID = ObjectTypeDataSourceID, id, ""
+		}
+		p := *page
+		p.Parent = &parent
+		page = &p
+	}
+
 	buf := new(bytes.Buffer)
 	if err := json.NewEncoder(buf).Encode(page); err != nil {
 		return nil, fmt.Errorf("notion: failed to encode request body: %v", err)
@@ -433,6 +646,71 @@ func (c *Client) CreatePage(ctx context.Context, page *Page) (*Page, error) {
 	return obj, nil
 }
 
+// CreatePageFromTemplatePage creates the page and copies the blocks of the template page into the new page.
+// This is useful when the template of the database is not available (e.g. the template is a normal page).
+// If the template page has the blocks which can't be created by the API (e.g. child_page), these blocks are skipped.
+func (c *Client) CreatePageFromTemplatePage(ctx context.Context, page *Page, templatePageID string) (*Page, error) {
+	if page.Template != nil {
+		return nil, errors.New("notion: template and template page can't be specified at the same time")
+	}
+
+	newPage, err := c.CreatePage(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.CopyBlocks(ctx, templatePageID, newPage.ID); err != nil {
+		return newPage, err
+	}
+
+	return newPage, nil
+}
+
+// ListTemplates can get all templates of the data source.
+// ref: https://developers.notion.com/reference/list-data-source-templates
+func (c *Client) ListTemplates(ctx context.Context, dataSourceID string) ([]*Template, error) {
+	if !c.supportsDataSource() {
+		return nil, c.unsupportedVersionError(Version20250903)
+	}
+
+	params := &url.Values{}
+	params.Set("page_size", "100")
+
+	templates := make([]*Template, 0)
+	for {
+		req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/data_sources/%s/templates", dataSourceID), params, nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		switch res.StatusCode {
+		case http.StatusOK:
+		default:
+			//goland:noinspection GoDeferInLoop
+			defer res.Body.Close()
+			return nil, c.decodeError(res)
+		}
+
+		obj := &TemplateList{}
+		if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+			res.Body.Close()
+			return nil, fmt.Errorf("failed parse a response: %v", err)
+		}
+		templates = append(templates, obj.Templates...)
+		res.Body.Close()
+
+		if !obj.HasMore {
+			break
+		}
+		params.Set("start_cursor", obj.NextCursor)
+	}
+
+	return templates, nil
+}
+
 // UpdateProperties can add and update the property.
 // ref: https://developers.notion.com/reference/patch-page
 func (c *Client) UpdateProperties(ctx context.Context, pageID string, properties map[string]*PropertyData) (*Page, error) {
@@ -473,6 +751,273 @@ func (c *Client) UpdateProperties(ctx context.Context, pageID string, properties
 	return obj, nil
 }
 
+// ArchivePage archives the page.
+// ref: https://developers.notion.com/reference/archive-a-page
+func (c *Client) ArchivePage(ctx context.Context, pageID string) (*Page, error) {
+	body := struct {
+		Archived bool `json:"archived"`
+	}{
+		Archived: true,
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return nil, fmt.Errorf("notion: failed to encode request body: %v", err)
+	}
+	req, err := c.newRequest(ctx, http.MethodPatch, fmt.Sprintf("/pages/%s", pageID), nil, buf)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	default:
+		return nil, c.decodeError(res)
+	}
+
+	obj := &Page{}
+	if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+		return nil, fmt.Errorf("failed parse a response: %v", err)
+	}
+	if err := obj.decode(); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// ErrSchemaMismatch is returned by MovePage when the new parent database can't accept the properties of the page.
+var ErrSchemaMismatch = errors.New("notion: schema mismatch")
+
+// MovePage moves the page to newParent.
+// newParent has to be a page, a database, a data source or the workspace.
+// When the page is moved to a database, MovePage checks that the database has all properties which have the value in the page.
+// ref: https://developers.notion.com/reference/move-page
+func (c *Client) MovePage(ctx context.Context, pageID string, newParent *PageParent) (*Page, error) {
+	if newParent == nil {
+		return nil, errors.New("notion: not specified new parent")
+	}
+	parent := *newParent
+	switch {
+	case parent.DatabaseID != "" || parent.DataSourceID != "":
+		if err := c.validateMove(ctx, pageID, &parent); err != nil {
+			return nil, err
+		}
+	case parent.PageID != "":
+		if parent.Type == "" {
+			parent.Type = ObjectTypePageID
+		}
+	case parent.Workspace:
+		if parent.Type == "" {
+			parent.Type = ObjectTypeWorkspace
+		}
+	default:
+		return nil, errors.New("notion: not specified new parent")
+	}
+	if c.supportsDataSource() {
+		parent.resolveDataSource()
+	}
+
+	body := struct {
+		Parent *PageParent `json:"parent"`
+	}{
+		Parent: &parent,
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return nil, fmt.Errorf("notion: failed to encode request body: %v", err)
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/pages/%s/move", pageID), nil, buf)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	default:
+		return nil, c.decodeError(res)
+	}
+
+	obj := &Page{}
+	if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+		return nil, fmt.Errorf("failed parse a response: %v", err)
+	}
+	if err := obj.decode(); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// validateMove checks that the page can be moved to the parent database or data source.
+// The schema of the parent is retrieved if the parent doesn't have it.
+func (c *Client) validateMove(ctx context.Context, pageID string, parent *PageParent) error {
+	page, err := c.GetPage(ctx, pageID)
+	if err != nil {
+		return err
+	}
+
+	if parent.DataSourceID != "" {
+		if parent.Type == "" {
+			parent.Type = ObjectTypeDataSourceID
+		}
+		if parent.DataSource == nil {
+			ds, err := c.GetDataSource(ctx, parent.DataSourceID)
+			if err != nil {
+				return err
+			}
+			parent.DataSource = ds
+		}
+		return page.checkSchema(parent.DataSource.Properties)
+	}
+
+	if parent.Type == "" {
+		parent.Type = ObjectTypeDatabaseID
+	}
+	if parent.Database == nil {
+		db, err := c.GetDatabase(ctx, parent.DatabaseID)
+		if err != nil {
+			return err
+		}
+		parent.Database = db
+	}
+	properties := parent.Database.Properties
+	// Since 2025-09-03, the database doesn't have the properties. The data source has them.
+	if len(properties) == 0 && c.supportsDataSource() && len(parent.Database.DataSources) == 1 {
+		ds, err := c.GetDataSource(ctx, parent.Database.DataSources[0].ID)
+		if err != nil {
+			return err
+		}
+		properties = ds.Properties
+	}
+	return page.checkSchema(properties)
+}
+
+var (
+	// ErrDuplicateKey is returned by UpsertPage when multiple pages have the same key.
+	ErrDuplicateKey = errors.New("notion: duplicate key")
+	// ErrPageNotFound is returned by UpsertPage when no page has the unique_id.
+	ErrPageNotFound = errors.New("notion: page not found")
+)
+
+// UpsertPage creates the page if the page which has keyValue in keyProperty doesn't exist, otherwise updates the page.
+// keyProperty has to be the name of title, rich_text or unique_id property. keyValue must not be empty.
+// For unique_id, keyValue is the number with or without the prefix (e.g. "TASK-15" or "15").
+// The prefix has to be the same as the prefix in the schema.
+// unique_id can't be specified when creating the page, so UpsertPage returns ErrPageNotFound instead of creating the page.
+// If multiple pages have the same key, UpsertPage returns ErrDuplicateKey.
+//
+// Since 2025-09-03, UpsertPage retrieves the data source of db to read the schema, and queries the data source.
+// If db has multiple data sources, UpsertPage returns ErrMultipleDataSources.
+func (c *Client) UpsertPage(ctx context.Context, db *Database, keyProperty, keyValue string, properties map[string]*PropertyData) (*Page, error) {
+	parent := &PageParent{Type: ObjectTypeDatabaseID, DatabaseID: db.ID, Database: db}
+	schemas := db.Properties
+	if c.supportsDataSource() {
+		var dataSourceID string
+		var err error
+		if len(db.DataSources) > 0 {
+			dataSourceID, err = dataSourceIDOf(db)
+		} else {
+			dataSourceID, err = c.ResolveDataSourceID(ctx, db.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ds, err := c.GetDataSource(ctx, dataSourceID)
+		if err != nil {
+			return nil, err
+		}
+		parent = &PageParent{Type: ObjectTypeDataSourceID, DataSourceID: ds.ID, DataSource: ds}
+		schemas = ds.Properties
+	}
+	schema, ok := schemas[keyProperty]
+	if !ok {
+		return nil, fmt.Errorf("notion: property %s is not found", keyProperty)
+	}
+	// The empty value is omitted from the filter, and then the filter matches any page.
+	if keyValue == "" {
+		return nil, errors.New("notion: the key is empty")
+	}
+
+	filter := &Filter{Property: keyProperty}
+	var keyData *PropertyData
+	switch schema.Type {
+	case PropertyTypeTitle:
+		filter.Title = &RichTextFilter{Equals: keyValue}
+		keyData = &PropertyData{Type: PropertyTypeTitle, Title: []*RichTextObject{{Type: RichTextObjectTypeText, Text: &Text{Content: keyValue}}}}
+	case PropertyTypeRichText:
+		filter.RichText = &RichTextFilter{Equals: keyValue}
+		keyData = &PropertyData{Type: PropertyTypeRichText, RichText: []*RichTextObject{{Type: RichTextObjectTypeText, Text: &Text{Content: keyValue}}}}
+	case PropertyTypeUniqueID:
+		v := keyValue
+		if i := strings.LastIndex(v, "-"); i >= 0 {
+			// The unique_id of the other prefix must not match the page which has the same number.
+			var prefix string
+			if schema.UniqueID != nil {
+				prefix = schema.UniqueID.Prefix
+			}
+			if v[:i] != prefix {
+				return nil, fmt.Errorf("notion: the prefix of unique_id %q doesn't match %q of %s", keyValue, prefix, keyProperty)
+			}
+			v = v[i+1:]
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("notion: invalid unique_id %q: %v", keyValue, err)
+		}
+		if n <= 0 {
+			return nil, fmt.Errorf("notion: invalid unique_id %q", keyValue)
+		}
+		filter.UniqueID = &UniqueIDFilter{Equals: n}
+	default:
+		return nil, fmt.Errorf("notion: %s property can't be used as the key", schema.Type)
+	}
+
+	var pages []*Page
+	var err error
+	if parent.DataSourceID != "" {
+		pages, err = c.QueryDataSource(ctx, parent.DataSourceID, filter, nil)
+	} else {
+		pages, err = c.GetPages(ctx, db.ID, filter, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(pages) {
+	case 0:
+		if schema.Type == PropertyTypeUniqueID {
+			return nil, fmt.Errorf("%w: %s=%s", ErrPageNotFound, keyProperty, keyValue)
+		}
+		page := &Page{
+			Parent:     parent,
+			Properties: make(map[string]*PropertyData),
+		}
+		for k, v := range properties {
+			page.Properties[k] = v
+		}
+		if _, ok := page.Properties[keyProperty]; !ok && keyData != nil {
+			page.Properties[keyProperty] = keyData
+		}
+		return c.CreatePage(ctx, page)
+	case 1:
+		return c.UpdateProperties(ctx, pages[0].ID, properties)
+	default:
+		return nil, fmt.Errorf("%w: %d pages have %s=%s", ErrDuplicateKey, len(pages), keyProperty, keyValue)
+	}
+}
+
 // AppendBlock is appending new children block.
 // ref: https://developers.notion.com/reference/patch-block-children
 func (c *Client) AppendBlock(ctx context.Context, blockID string, children []*Block) ([]*Block, error) {
@@ -510,91 +1055,152 @@ func (c *Client) AppendBlock(ctx context.Context, blockID string, children []*Bl
 	return obj.Results, nil
 }
 
-func (c *Client) Search(ctx context.Context, query string, sort *Sort) ([]Object, error) {
-	body := struct {
-		Query       string `json:"query"`
-		Sort        *Sort  `json:"sort,omitempty"`
-		StartCursor string `json:"start_cursor,omitempty"`
-		PageSize    int    `json:"page_size"`
-	}{
-		Query:    query,
-		Sort:     sort,
-		PageSize: 100,
+// CopyBlocks copies the children of the block (or the page) to another block recursively.
+// The blocks which can't be created by the API are skipped:
+// child_page, child_database and the file blocks which are hosted by Notion (the URL of the file expires).
+func (c *Client) CopyBlocks(ctx context.Context, fromBlockID, toBlockID string) error {
+	copies, err := c.copyChildren(ctx, fromBlockID)
+	if err != nil {
+		return err
 	}
 
-	objs := make([]Object, 0)
-	tmp := make([]Object, 0, 100)
-	buf := new(bytes.Buffer)
-	for {
-		if err := json.NewEncoder(buf).Encode(body); err != nil {
-			return nil, err
+	for i := 0; i < len(copies); i += maxAppendBlocks {
+		end := i + maxAppendBlocks
+		if end > len(copies) {
+			end = len(copies)
 		}
-
-		req, err := c.newRequest(ctx, http.MethodPost, "/search", nil, buf)
-		if err != nil {
-			return nil, err
+		children := make([]*Block, 0, end-i)
+		for _, v := range copies[i:end] {
+			children = append(children, v.dst)
 		}
-		res, err := c.httpClient.Do(req)
+		created, err := c.AppendBlock(ctx, toBlockID, children)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if len(created) != end-i {
+			return fmt.Errorf("notion: unexpected number of appended blocks: %d, expected %d", len(created), end-i)
 		}
 
-		switch res.StatusCode {
-		case http.StatusOK:
-		default:
-			//goland:noinspection GoDeferInLoop
-			defer res.Body.Close()
-			return nil, c.decodeError(res)
+		for j, v := range copies[i:end] {
+			if err := c.copyDescendants(ctx, v, created[j]); err != nil {
+				return err
+			}
 		}
+	}
 
-		obj := &SearchResult{}
-		if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
-			res.Body.Close()
-			return nil, fmt.Errorf("failed parse a response: %v", err)
+	return nil
+}
+
+// blockCopy is the copy of the block which will be created.
+type blockCopy struct {
+	src *Block
+	dst *Block
+	// embedded is true if the children are created with the block.
+	embedded bool
+	children []*blockCopy
+}
+
+// pending reports whether the block has the descendants which are not created with the block.
+func (b *blockCopy) pending() bool {
+	if !b.embedded {
+		return b.src.HasChildren
+	}
+	for _, v := range b.children {
+		if v.pending() {
+			return true
 		}
-		res.Body.Close()
+	}
+	return false
+}
+
+// copyChildren returns the copies of the children of the block.
+// The rows of the table, the columns of the column list and the children of the column
+// have to be created with the parent, so they are embedded into the copy of the parent.
+func (c *Client) copyChildren(ctx context.Context, blockID string) ([]*blockCopy, error) {
+	blocks, err := c.GetBlocks(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
 
-		meta := &Meta{}
-		for _, v := range obj.Results {
-			if err := json.Unmarshal(*v, meta); err != nil {
+	copies := make([]*blockCopy, 0, len(blocks))
+	for _, v := range blocks {
+		if !v.copyable() {
+			continue
+		}
+		bc := &blockCopy{src: v, dst: v.copy()}
+		if bc.dst.CallOut != nil && bc.dst.CallOut.Icon != nil && bc.dst.CallOut.Icon.Type == IconTypeFile {
+			// The icon which is hosted by Notion can't be created as well as the file block.
+			callout := *bc.dst.CallOut
+			callout.Icon = nil
+			bc.dst.CallOut = &callout
+		}
+		switch v.Type {
+		case BlockTypeTable, BlockTypeColumnList, BlockTypeColumn:
+			bc.embedded = true
+			bc.children, err = c.copyChildren(ctx, v.ID)
+			if err != nil {
 				return nil, err
 			}
-
-			switch meta.Object {
-			case "database":
-				db := &Database{}
-				if err := json.Unmarshal(*v, db); err != nil {
-					return nil, err
-				}
-				if err := db.decode(); err != nil {
-					return nil, err
-				}
-				tmp = append(tmp, db)
-			case "page":
-				page := &Page{}
-				if err := json.Unmarshal(*v, page); err != nil {
-					return nil, err
-				}
-				if err := page.decode(); err != nil {
-					return nil, err
-				}
-				tmp = append(tmp, page)
-			default:
-				return nil, fmt.Errorf("notion: unknown object type: %s", meta.Object)
+			children := make([]*Block, 0, len(bc.children))
+			for _, child := range bc.children {
+				children = append(children, child.dst)
 			}
+			bc.dst.setChildren(children)
 		}
-		objs = append(objs, tmp...)
-		tmp = tmp[:0]
+		copies = append(copies, bc)
+	}
 
-		if !obj.HasMore {
-			break
+	return copies, nil
+}
+
+// copyDescendants copies the descendants of the block which are not created with the block.
+func (c *Client) copyDescendants(ctx context.Context, bc *blockCopy, created *Block) error {
+	if !bc.pending() {
+		return nil
+	}
+	if !bc.embedded {
+		return c.CopyBlocks(ctx, bc.src.ID, created.ID)
+	}
+
+	children, err := c.GetBlocks(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	if len(children) != len(bc.children) {
+		return fmt.Errorf("notion: unexpected number of created blocks: %d, expected %d", len(children), len(bc.children))
+	}
+	for i, v := range bc.children {
+		if err := c.copyDescendants(ctx, v, children[i]); err != nil {
+			return err
 		}
-		body.StartCursor = obj.NextCursor
+	}
+
+	return nil
+}
+
+// Search can search pages and databases by the title.
+// ref: https://developers.notion.com/reference/post-search
+func (c *Client) Search(ctx context.Context, query string, sort *Sort) ([]Object, error) {
+	objs, err := c.search(ctx, query, sort, "")
+	if err != nil {
+		return nil, unwrapPaginationError(err)
 	}
 
 	return objs, nil
 }
 
+// SearchFrom is the same as Search except that the pagination starts from startCursor.
+// If the pagination is interrupted, SearchFrom returns the objects which are collected so far with *PaginationError.
+// The search can be resumed by PaginationError.NextCursor.
+func (c *Client) SearchFrom(ctx context.Context, query string, sort *Sort, startCursor string) ([]Object, error) {
+	return c.search(ctx, query, sort, startCursor)
+}
+
+// search returns the objects which are collected so far with *PaginationError if the pagination is interrupted.
+func (c *Client) search(ctx context.Context, query string, sort *Sort, startCursor string) ([]Object, error) {
+	return collect(newIterator(ctx, startCursor, c.searchFetcher(query, sort), decodeSearchResult))
+}
+
 // CreateDatabase creates a database
 // ref: https://developers.notion.com/reference/create-a-database
 func (c *Client) CreateDatabase(ctx context.Context, db *Database) (*Database, error) {
@@ -629,6 +1235,165 @@ func (c *Client) CreateDatabase(ctx context.Context, db *Database) (*Database, e
 	return obj, nil
 }
 
+// UploadFile uploads the file and returns the file upload object.
+// The file which is larger than 20MB is uploaded by multi-part mode.
+// If the size of r can't be determined, UploadFile will read all of r into memory.
+// The uploaded file can be attached to the files property and blocks by FileUpload.File and FileUpload.Block.
+// ref: https://developers.notion.com/reference/create-a-file-upload
+func (c *Client) UploadFile(ctx context.Context, name, contentType string, r io.Reader) (*FileUpload, error) {
+	size := readerSize(r)
+	if size < 0 {
+		buf, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("notion: failed to read the file: %v", err)
+		}
+		r = bytes.NewReader(buf)
+		size = int64(len(buf))
+	}
+
+	if size <= fileUploadSinglePartMaxSize {
+		upload, err := c.createFileUpload(ctx, FileUploadModeSinglePart, name, contentType, 0)
+		if err != nil {
+			return nil, err
+		}
+		return c.sendFileUpload(ctx, upload.ID, name, contentType, r, 0)
+	}
+
+	parts := int((size + fileUploadPartSize - 1) / fileUploadPartSize)
+	upload, err := c.createFileUpload(ctx, FileUploadModeMultiPart, name, contentType, parts)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i <= parts; i++ {
+		if _, err := c.sendFileUpload(ctx, upload.ID, name, contentType, io.LimitReader(r, fileUploadPartSize), i); err != nil {
+			return nil, err
+		}
+	}
+	return c.completeFileUpload(ctx, upload.ID)
+}
+
+// createFileUpload creates the file upload object.
+// numberOfParts is used only in multi-part mode.
+func (c *Client) createFileUpload(ctx context.Context, mode FileUploadMode, name, contentType string, numberOfParts int) (*FileUpload, error) {
+	body := struct {
+		Mode          FileUploadMode `json:"mode"`
+		Filename      string         `json:"filename,omitempty"`
+		ContentType   string         `json:"content_type,omitempty"`
+		NumberOfParts int            `json:"number_of_parts,omitempty"`
+	}{
+		Mode:          mode,
+		Filename:      name,
+		ContentType:   contentType,
+		NumberOfParts: numberOfParts,
+	}
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return nil, fmt.Errorf("notion: failed to encode request body: %v", err)
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "/file_uploads", nil, buf)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	default:
+		return nil, c.decodeError(res)
+	}
+
+	obj := &FileUpload{}
+	if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+		return nil, fmt.Errorf("notion: failed parse a response: %v", err)
+	}
+
+	return obj, nil
+}
+
+// sendFileUpload sends the contents of the file.
+// partNumber is used only in multi-part mode. The first part is 1.
+// ref: https://developers.notion.com/reference/send-a-file-upload
+func (c *Client) sendFileUpload(ctx context.Context, uploadID, name, contentType string, r io.Reader, partNumber int) (*FileUpload, error) {
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, escapeQuotes(name)))
+	if contentType != "" {
+		h.Set("Content-Type", contentType)
+	}
+	fw, err := w.CreatePart(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(fw, r); err != nil {
+		return nil, fmt.Errorf("notion: failed to read the file: %v", err)
+	}
+	if partNumber > 0 {
+		if err := w.WriteField("part_number", strconv.Itoa(partNumber)); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/file_uploads/%s/send", uploadID), nil, buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	default:
+		return nil, c.decodeError(res)
+	}
+
+	obj := &FileUpload{}
+	if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+		return nil, fmt.Errorf("notion: failed parse a response: %v", err)
+	}
+
+	return obj, nil
+}
+
+// completeFileUpload completes the file upload in multi-part mode.
+// ref: https://developers.notion.com/reference/complete-a-file-upload
+func (c *Client) completeFileUpload(ctx context.Context, uploadID string) (*FileUpload, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/file_uploads/%s/complete", uploadID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	default:
+		return nil, c.decodeError(res)
+	}
+
+	obj := &FileUpload{}
+	if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+		return nil, fmt.Errorf("notion: failed parse a response: %v", err)
+	}
+
+	return obj, nil
+}
+
 func (c *Client) newRequest(ctx context.Context, method string, apiPath string, params *url.Values, body io.Reader) (*http.Request, error) {
 	u := &url.URL{}
 	*u = *c.baseURL
@@ -640,7 +1405,7 @@ func (c *Client) newRequest(ctx context.Context, method string, apiPath string,
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("Notion-Version", notionVersion)
+	req.Header.Add("Notion-Version", c.version)
 	req.Header.Add("User-Agent", UserAgent)
 	if body != nil {
 		req.Header.Add("Content-Type", "application/json")
@@ -649,6 +1414,59 @@ func (c *Client) newRequest(ctx context.Context, method string, apiPath string,
 	return req, nil
 }
 
+// supportsDataSource reports whether the version of the client has data sources.
+// Notion-Version is a date so that the versions can be compared as a string.
+func (c *Client) supportsDataSource() bool {
+	return c.version >= Version20250903
+}
+
+func (c *Client) unsupportedVersionError(required string) error {
+	return fmt.Errorf("notion: Notion-Version %s or later is required but the client uses %s", required, c.version)
+}
+
+func (c *Client) removedVersionError(removed string) error {
+	return fmt.Errorf("notion: the API is not available since Notion-Version %s but the client uses %s", removed, c.version)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// readerSize returns the remaining size of r.
+// readerSize returns -1 if the size can't be determined.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len())
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := v.Seek(cur, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - cur
+	}
+
+	return -1
+}
+
+// unwrapPaginationError returns the cause of the error for the methods which discard the partial results.
+func unwrapPaginationError(err error) error {
+	var pErr *PaginationError
+	if errors.As(err, &pErr) {
+		return pErr.Err
+	}
+	return err
+}
+
 func (c *Client) decodeError(res *http.Response) error {
 	err := &Error{}
 	if err := json.NewDecoder(res.Body).Decode(err); err != nil {
@@ -1,11 +1,18 @@
 package notion
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -365,7 +372,7 @@ func TestGetPages(t *testing.T) {
 	}
 
 	assert.Equal(t, PropertyTypeNumber, page.Properties["Test2"].Type)
-	assert.Equal(t, ptr(190), page.Properties["Test2"].Number)
+	assert.Equal(t, ptr(190.0), page.Properties["Test2"].Number)
 
 	assert.Equal(t, PropertyTypeSelect, page.Properties["Test3"].Type)
 	if assert.NotNil(t, page.Properties["Test3"].Select) {
@@ -546,7 +553,7 @@ func TestGetPage(t *testing.T) {
 	}
 
 	assert.Equal(t, "number", page.Properties["Test2"].Type)
-	assert.Equal(t, 190, page.Properties["Test2"].Number)
+	assert.Equal(t, ptr(190.0), page.Properties["Test2"].Number)
 
 	assert.Equal(t, "select", page.Properties["Test3"].Type)
 	if assert.NotNil(t, page.Properties["Test3"].Select) {
@@ -831,6 +838,83 @@ func TestGetBlocksChildren(t *testing.T) {
 	assert.Equal(t, "column", blocks[1].Type)
 }
 
+func TestGetBlocks_AllTypes(t *testing.T) {
+	t.Parallel()
+
+	rt := mockTransport(t, http.MethodGet, `/v1/blocks/[a-z0-9-]{36}/children`, http.StatusOK, "./testdata/get-block-children-all-types.json")
+	client, err := New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+
+	blocks, err := client.GetBlocks(context.Background(), "16493215-50a8-41b8-8b43-0a0c014a7910")
+	require.NoError(t, err)
+	require.Len(t, blocks, 16)
+
+	if assert.NotNil(t, blocks[0].Heading2) {
+		assert.True(t, blocks[0].Heading2.IsToggleable)
+	}
+	if assert.NotNil(t, blocks[1].CallOut) {
+		assert.Equal(t, "Note", blocks[1].CallOut.RichText[0].PlainText)
+		assert.Equal(t, IconTypeEmoji, blocks[1].CallOut.Icon.Type)
+		assert.Equal(t, "💡", blocks[1].CallOut.Icon.Emoji)
+		assert.Equal(t, "gray_background", blocks[1].CallOut.Color)
+	}
+	if assert.NotNil(t, blocks[2].Quote) {
+		assert.Equal(t, "Quoted", blocks[2].Quote.RichText[0].PlainText)
+	}
+	if assert.NotNil(t, blocks[3].ToDo) {
+		assert.Equal(t, "Task", blocks[3].ToDo.RichText[0].PlainText)
+		assert.True(t, blocks[3].ToDo.Checked)
+	}
+	if assert.NotNil(t, blocks[4].Code) {
+		assert.Equal(t, "main.go", blocks[4].Code.Caption[0].PlainText)
+		assert.Equal(t, "go", blocks[4].Code.Language)
+	}
+	if assert.NotNil(t, blocks[5].Image) {
+		assert.Equal(t, FileTypeFile, blocks[5].Image.Type)
+		assert.Equal(t, "https://s3.us-west-2.amazonaws.com/secure.notion-static.com/image.png", blocks[5].Image.URL())
+		assert.Equal(t, int64(1709290800), blocks[5].Image.File.ExpiryTime.Unix())
+	}
+	if assert.NotNil(t, blocks[6].Video) {
+		assert.Equal(t, FileTypeExternal, blocks[6].Video.Type)
+		assert.Equal(t, "https://www.youtube.com/watch?v=example", blocks[6].Video.URL())
+	}
+	if assert.NotNil(t, blocks[7].Bookmark) {
+		assert.Equal(t, "https://example.com", blocks[7].Bookmark.URL)
+	}
+	if assert.NotNil(t, blocks[8].Equation) {
+		assert.Equal(t, "e=mc^2", blocks[8].Equation.Expression)
+	}
+	if assert.NotNil(t, blocks[9].Embed) {
+		assert.Equal(t, "https://example.com/embed", blocks[9].Embed.URL)
+	}
+	if assert.NotNil(t, blocks[10].LinkPreview) {
+		assert.Equal(t, "https://github.com/f110/notion-api", blocks[10].LinkPreview.URL)
+	}
+	if assert.NotNil(t, blocks[11].LinkToPage) {
+		assert.Equal(t, ObjectTypePageID, blocks[11].LinkToPage.Type)
+		assert.Equal(t, "c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1", blocks[11].LinkToPage.PageID)
+	}
+	if assert.NotNil(t, blocks[12].Synced) && assert.NotNil(t, blocks[12].Synced.SyncedFrom) {
+		assert.Equal(t, "a1c0f6f6-1b2e-4c9a-9d3e-000000000099", blocks[12].Synced.SyncedFrom.BlockID)
+	}
+	assert.NotNil(t, blocks[13].Column)
+	if assert.NotNil(t, blocks[14].ChildDatabase) {
+		assert.Equal(t, "Tasks", blocks[14].ChildDatabase.Title)
+	}
+	if assert.NotNil(t, blocks[15].Audio) {
+		assert.Equal(t, "https://example.com/audio.mp3", blocks[15].Audio.URL())
+	}
+
+	// The content is kept when the block is encoded again.
+	for _, v := range blocks {
+		buf, err := json.Marshal(v)
+		require.NoError(t, err)
+		m := make(map[string]json.RawMessage)
+		require.NoError(t, json.Unmarshal(buf, &m))
+		assert.Contains(t, m, string(v.Type))
+	}
+}
+
 func TestUpdateBlock(t *testing.T) {
 	t.Parallel()
 
@@ -1050,6 +1134,1056 @@ func TestCreateDatabase(t *testing.T) {
 	require.NotNil(t, db.Properties["Test1"])
 }
 
+func TestGetDataSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		rt := httpmock.NewMockTransport()
+		res, err := os.ReadFile("./testdata/get-data-source.json")
+		require.NoError(t, err)
+		rt.RegisterRegexpResponder(
+			http.MethodGet,
+			regexp.MustCompile(`/v1/data_sources/[a-z0-9-]{36}$`),
+			func(req *http.Request) (*http.Response, error) {
+				if req.Header.Get("Notion-Version") != Version20250903 {
+					return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+				}
+				return httpmock.NewStringResponse(http.StatusOK, string(res)), nil
+			},
+		)
+
+		client, err := New(&http.Client{Transport: rt}, "https://example.com", WithVersion(Version20250903))
+		require.NoError(t, err)
+
+		ds, err := client.GetDataSource(context.Background(), "248104cd-477e-80af-bc30-000bd28de8f9")
+		require.NoError(t, err)
+
+		assert.Equal(t, "248104cd-477e-80af-bc30-000bd28de8f9", ds.ID)
+		assert.Equal(t, ObjectTypeDataSource, ds.Object)
+		if assert.NotNil(t, ds.Parent) {
+			assert.Equal(t, ObjectTypeDatabaseID, ds.Parent.Type)
+			assert.Equal(t, "ba8e1263-af24-4cd0-87e0-6e2933303b60", ds.Parent.DatabaseID)
+		}
+		if assert.NotNil(t, ds.DatabaseParent) {
+			assert.Equal(t, "a4f18e20-365d-4fe1-91e8-080381f877d5", ds.DatabaseParent.PageID)
+		}
+		assert.Len(t, ds.Properties, 2)
+		require.NotNil(t, ds.Properties["Test1"])
+		assert.Equal(t, ":UPp", ds.Properties["Test1"].ID)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := New(&http.Client{Transport: httpmock.NewMockTransport()}, "https://example.com")
+		require.NoError(t, err)
+
+		_, err = client.GetDataSource(context.Background(), "248104cd-477e-80af-bc30-000bd28de8f9")
+		assert.Error(t, err)
+	})
+}
+
+func TestQueryDataSource(t *testing.T) {
+	t.Parallel()
+
+	rt := mockTransport(t, http.MethodPost, `/v1/data_sources/[a-z0-9-]{36}/query$`, http.StatusOK, "./testdata/post-data-source-query.json")
+
+	client, err := New(&http.Client{Transport: rt}, "https://example.com", WithVersion(Version20250903))
+	require.NoError(t, err)
+
+	pages, err := client.QueryDataSource(context.Background(), "248104cd-477e-80af-bc30-000bd28de8f9", nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, pages, 1)
+	page := pages[0]
+	assert.Equal(t, "16493215-50a8-41b8-8b43-0a0c014a7910", page.ID)
+	if assert.NotNil(t, page.Parent) {
+		assert.Equal(t, ObjectTypeDataSourceID, page.Parent.Type)
+		assert.Equal(t, "248104cd-477e-80af-bc30-000bd28de8f9", page.Parent.DataSourceID)
+		assert.Equal(t, "ba8e1263-af24-4cd0-87e0-6e2933303b60", page.Parent.DatabaseID)
+	}
+	assert.Equal(t, "Foo", page.Properties["Name"].String())
+}
+
+func TestUpdateDataSource(t *testing.T) {
+	t.Parallel()
+
+	rt := mockTransport(t, http.MethodPatch, `/v1/data_sources/[a-z0-9-]{36}$`, http.StatusOK, "./testdata/get-data-source.json")
+
+	client, err := New(&http.Client{Transport: rt}, "https://example.com", WithVersion(Version20250903))
+	require.NoError(t, err)
+
+	ds, err := client.UpdateDataSource(context.Background(), &DataSource{
+		Meta: &Meta{ID: "248104cd-477e-80af-bc30-000bd28de8f9"},
+		Properties: map[string]*PropertyMetadata{
+			"Test1": {Name: "Test1"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "248104cd-477e-80af-bc30-000bd28de8f9", ds.ID)
+	require.NotNil(t, ds.Properties["Test1"])
+}
+
+func TestCreatePageInDataSource(t *testing.T) {
+	t.Parallel()
+
+	rt := httpmock.NewMockTransport()
+	res, err := os.ReadFile("./testdata/post-page.json")
+	require.NoError(t, err)
+	var parent *PageParent
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/pages$`),
+		func(req *http.Request) (*http.Response, error) {
+			page := &Page{}
+			if err := json.NewDecoder(req.Body).Decode(page); err != nil {
+				return nil, err
+			}
+			parent = page.Parent
+			return httpmock.NewStringResponse(http.StatusOK, string(res)), nil
+		},
+	)
+
+	client, err := New(&http.Client{Transport: rt}, "https://example.com", WithVersion(Version20250903))
+	require.NoError(t, err)
+
+	db := &Database{
+		Meta: &Meta{ID: "ba8e1263-af24-4cd0-87e0-6e2933303b60"},
+		Properties: map[string]*PropertyMetadata{
+			"Name": {ID: "title", Type: PropertyTypeTitle},
+		},
+		DataSources: []*DataSourceReference{{ID: "248104cd-477e-80af-bc30-000bd28de8f9"}},
+	}
+	page, err := NewPage(db, "Foo", nil)
+	require.NoError(t, err)
+	_, err = client.CreatePage(context.Background(), page)
+	require.NoError(t, err)
+
+	if assert.NotNil(t, parent) {
+		assert.Equal(t, ObjectTypeDataSourceID, parent.Type)
+		assert.Equal(t, "248104cd-477e-80af-bc30-000bd28de8f9", parent.DataSourceID)
+		assert.Empty(t, parent.DatabaseID)
+	}
+	// The parent of the caller is not rewritten.
+	assert.Equal(t, "ba8e1263-af24-4cd0-87e0-6e2933303b60", page.Parent.DatabaseID)
+	assert.Empty(t, page.Parent.DataSourceID)
+}
+
+func TestGetPages_RemovedVersion(t *testing.T) {
+	t.Parallel()
+
+	client, err := New(&http.Client{Transport: httpmock.NewMockTransport()}, "https://example.com", WithVersion(Version20250903))
+	require.NoError(t, err)
+
+	_, err = client.GetPages(context.Background(), "a4f18e20-365d-4fe1-91e8-080381f877d5", nil, nil)
+	assert.Error(t, err)
+	_, err = client.GetPagesFrom(context.Background(), "a4f18e20-365d-4fe1-91e8-080381f877d5", nil, nil, "")
+	assert.Error(t, err)
+}
+
+func TestQueryDatabase(t *testing.T) {
+	t.Parallel()
+
+	newTransport := func(dataSources ...string) *httpmock.MockTransport {
+		rt := httpmock.NewMockTransport()
+		refs := make([]map[string]any, 0, len(dataSources))
+		for _, v := range dataSources {
+			refs = append(refs, map[string]any{"id": v, "name": "Tasks"})
+		}
+		rt.RegisterRegexpResponder(
+			http.MethodGet,
+			regexp.MustCompile(`/v1/databases/ba8e1263-af24-4cd0-87e0-6e2933303b60$`),
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{"object": "database", "id": "ba8e1263-af24-4cd0-87e0-6e2933303b60", "data_sources": refs}),
+		)
+		res, err := os.ReadFile("./testdata/post-data-source-query.json")
+		require.NoError(t, err)
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/data_sources/248104cd-477e-80af-bc30-000bd28de8f9/query$`),
+			httpmock.NewStringResponder(http.StatusOK, string(res)),
+		)
+		return rt
+	}
+
+	client, err := New(&http.Client{Transport: newTransport("248104cd-477e-80af-bc30-000bd28de8f9")}, "https://example.com", WithVersion(Version20250903))
+	require.NoError(t, err)
+	pages, err := client.QueryDatabase(context.Background(), "ba8e1263-af24-4cd0-87e0-6e2933303b60", nil, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, pages)
+
+	client, err = New(&http.Client{Transport: newTransport("248104cd-477e-80af-bc30-000bd28de8f9", "3c1a2f5e-6b4d-4f1e-9a2b-7c8d9e0f1a2b")}, "https://example.com", WithVersion(Version20250903))
+	require.NoError(t, err)
+	_, err = client.QueryDatabase(context.Background(), "ba8e1263-af24-4cd0-87e0-6e2933303b60", nil, nil)
+	assert.ErrorIs(t, err, ErrMultipleDataSources)
+
+	// The database is queried directly before 2025-09-03.
+	client, err = New(&http.Client{Transport: mockTransport(t, http.MethodPost, `/v1/databases/[a-z0-9-]{36}/query$`, http.StatusOK, "./testdata/post-database-query.json")}, "https://example.com")
+	require.NoError(t, err)
+	pages, err = client.QueryDatabase(context.Background(), "ba8e1263-af24-4cd0-87e0-6e2933303b60", nil, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, pages)
+}
+
+func TestCreatePage_ResolveDataSource(t *testing.T) {
+	t.Parallel()
+
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/databases/ba8e1263-af24-4cd0-87e0-6e2933303b60$`),
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{
+			"object":       "database",
+			"id":           "ba8e1263-af24-4cd0-87e0-6e2933303b60",
+			"data_sources": []map[string]any{{"id": "248104cd-477e-80af-bc30-000bd28de8f9"}},
+		}),
+	)
+	res, err := os.ReadFile("./testdata/post-page.json")
+	require.NoError(t, err)
+	var parent *PageParent
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/pages$`),
+		func(req *http.Request) (*http.Response, error) {
+			page := &Page{}
+			if err := json.NewDecoder(req.Body).Decode(page); err != nil {
+				return nil, err
+			}
+			parent = page.Parent
+			return httpmock.NewStringResponse(http.StatusOK, string(res)), nil
+		},
+	)
+
+	client, err := New(&http.Client{Transport: rt}, "https://example.com", WithVersion(Version20250903))
+	require.NoError(t, err)
+	_, err = client.CreatePage(context.Background(), &Page{
+		Parent:     &PageParent{Type: ObjectTypeDatabaseID, DatabaseID: "ba8e1263-af24-4cd0-87e0-6e2933303b60"},
+		Properties: map[string]*PropertyData{"Name": {Type: PropertyTypeTitle, Title: RichText().Text("Foo").Build()}},
+	})
+	require.NoError(t, err)
+	if assert.NotNil(t, parent) {
+		assert.Equal(t, ObjectTypeDataSourceID, parent.Type)
+		assert.Equal(t, "248104cd-477e-80af-bc30-000bd28de8f9", parent.DataSourceID)
+		assert.Empty(t, parent.DatabaseID)
+	}
+}
+
+func TestListTemplates(t *testing.T) {
+	t.Parallel()
+
+	client, err := New(&http.Client{Transport: mockTransport(t, http.MethodGet, `/v1/data_sources/[a-z0-9-]{36}/templates`, http.StatusOK, "./testdata/get-data-source-templates.json")}, "https://example.com", WithVersion(Version20250903))
+	require.NoError(t, err)
+
+	templates, err := client.ListTemplates(context.Background(), "248104cd-477e-80af-bc30-000bd28de8f9")
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "a5da15f6-b853-455f-a4ea-4d8a8c0e6a2b", templates[0].ID)
+	assert.Equal(t, "Weekly report", templates[0].Name)
+	assert.True(t, templates[0].IsDefault)
+	assert.False(t, templates[1].IsDefault)
+
+	client, err = New(&http.Client{Transport: httpmock.NewMockTransport()}, "https://example.com")
+	require.NoError(t, err)
+	_, err = client.ListTemplates(context.Background(), "248104cd-477e-80af-bc30-000bd28de8f9")
+	assert.Error(t, err)
+}
+
+func TestCreatePageWithTemplate(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/post-page.json")
+	require.NoError(t, err)
+	var template *PageTemplate
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/pages$`),
+		func(req *http.Request) (*http.Response, error) {
+			page := &Page{}
+			if err := json.NewDecoder(req.Body).Decode(page); err != nil {
+				return nil, err
+			}
+			template = page.Template
+			return httpmock.NewStringResponse(http.StatusOK, string(res)), nil
+		},
+	)
+	db := &Database{
+		Meta: &Meta{ID: "ba8e1263-af24-4cd0-87e0-6e2933303b60"},
+		Properties: map[string]*PropertyMetadata{
+			"Name": {ID: "title", Type: PropertyTypeTitle},
+		},
+		DataSources: []*DataSourceReference{{ID: "248104cd-477e-80af-bc30-000bd28de8f9"}},
+	}
+	page, err := NewPage(db, "Foo", nil)
+	require.NoError(t, err)
+	page.Template = &PageTemplate{Type: TemplateTypeTemplateID, TemplateID: "0d6a3cbb-8d0e-4a4c-9ba5-e6c4f4c1b7a1"}
+
+	client, err := New(&http.Client{Transport: rt}, "https://example.com", WithVersion(Version20250903))
+	require.NoError(t, err)
+	_, err = client.CreatePage(context.Background(), page)
+	require.NoError(t, err)
+	if assert.NotNil(t, template) {
+		assert.Equal(t, TemplateTypeTemplateID, template.Type)
+		assert.Equal(t, "0d6a3cbb-8d0e-4a4c-9ba5-e6c4f4c1b7a1", template.TemplateID)
+	}
+
+	client, err = New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+	_, err = client.CreatePage(context.Background(), page)
+	assert.Error(t, err)
+}
+
+func TestCreatePageFromTemplatePage(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/post-page.json")
+	require.NoError(t, err)
+	paragraph := func(id, text string, hasChildren bool) *Block {
+		return &Block{
+			Meta:        &Meta{Object: ObjectTypeBlock, ID: id},
+			HasChildren: hasChildren,
+			Type:        BlockTypeParagraph,
+			Paragraph:   &Paragraph{RichText: []*RichTextObject{{Type: RichTextObjectTypeText, Text: &Text{Content: text}}}},
+		}
+	}
+	tree := map[string][]*Block{
+		"c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1": {
+			paragraph("11111111-1111-1111-1111-111111111111", "Summary", false),
+			{Meta: &Meta{Object: ObjectTypeBlock, ID: "22222222-2222-2222-2222-222222222222"}, Type: BlockTypeChildPage, ChildPage: &ChildPage{Title: "Sub page"}},
+			paragraph("33333333-3333-3333-3333-333333333333", "Details", true),
+		},
+		"33333333-3333-3333-3333-333333333333": {
+			paragraph("44444444-4444-4444-4444-444444444444", "Nested", false),
+		},
+	}
+
+	var mu sync.Mutex
+	appended := make(map[string][]string)
+	var created int
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/pages$`),
+		httpmock.NewStringResponder(http.StatusOK, string(res)),
+	)
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/blocks/[a-z0-9-]{36}/children`),
+		func(req *http.Request) (*http.Response, error) {
+			id := strings.Split(req.URL.Path, "/")[3]
+			return httpmock.NewJsonResponse(http.StatusOK, &BlockList{ListMeta: &ListMeta{Object: ObjectTypeList}, Results: tree[id]})
+		},
+	)
+	rt.RegisterRegexpResponder(
+		http.MethodPatch,
+		regexp.MustCompile(`/v1/blocks/[a-z0-9-]{36}/children`),
+		func(req *http.Request) (*http.Response, error) {
+			body := struct {
+				Children []*Block `json:"children"`
+			}{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			id := strings.Split(req.URL.Path, "/")[3]
+			for _, v := range body.Children {
+				assert.Nil(t, v.Meta)
+				created++
+				v.Meta = &Meta{Object: ObjectTypeBlock, ID: fmt.Sprintf("%08d-0000-0000-0000-000000000000", created)}
+				appended[id] = append(appended[id], v.Paragraph.RichText[0].Text.Content)
+			}
+			return httpmock.NewJsonResponse(http.StatusOK, &BlockList{ListMeta: &ListMeta{Object: ObjectTypeList}, Results: body.Children})
+		},
+	)
+
+	client, err := New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+	page, err := client.CreatePageFromTemplatePage(context.Background(), &Page{Parent: &PageParent{DatabaseID: "ba8e1263-af24-4cd0-87e0-6e2933303b60"}}, "c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		page.ID:                                {"Summary", "Details"},
+		"00000002-0000-0000-0000-000000000000": {"Nested"},
+	}, appended)
+}
+
+func TestCopyBlocks_Columns(t *testing.T) {
+	t.Parallel()
+
+	paragraph := func(id, text string, hasChildren bool) *Block {
+		return &Block{
+			Meta:        &Meta{Object: ObjectTypeBlock, ID: id},
+			HasChildren: hasChildren,
+			Type:        BlockTypeParagraph,
+			Paragraph:   &Paragraph{RichText: []*RichTextObject{{Type: RichTextObjectTypeText, Text: &Text{Content: text}}}},
+		}
+	}
+	column := func(id string) *Block {
+		return &Block{Meta: &Meta{Object: ObjectTypeBlock, ID: id}, HasChildren: true, Type: BlockTypeColumn, Column: &Column{}}
+	}
+	tree := map[string][]*Block{
+		"c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1": {
+			{Meta: &Meta{Object: ObjectTypeBlock, ID: "11111111-1111-1111-1111-111111111111"}, HasChildren: true, Type: BlockTypeColumnList, ColumnList: &ColumnList{}},
+			{
+				Meta:  &Meta{Object: ObjectTypeBlock, ID: "22222222-2222-2222-2222-222222222222"},
+				Type:  BlockTypeImage,
+				Image: &FileBlock{Type: FileTypeFile, File: &NotionFile{URL: "https://s3.us-west-2.amazonaws.com/image.png"}},
+			},
+		},
+		"11111111-1111-1111-1111-111111111111": {column("33333333-3333-3333-3333-333333333333"), column("44444444-4444-4444-4444-444444444444")},
+		"33333333-3333-3333-3333-333333333333": {paragraph("55555555-5555-5555-5555-555555555555", "Left", true)},
+		"44444444-4444-4444-4444-444444444444": {paragraph("66666666-6666-6666-6666-666666666666", "Right", false)},
+		"55555555-5555-5555-5555-555555555555": {paragraph("77777777-7777-7777-7777-777777777777", "Nested", false)},
+	}
+
+	var mu sync.Mutex
+	var created int
+	var requests [][]*Block
+	var parents []string
+	// register assigns the IDs to the created blocks and registers the embedded children to the tree.
+	var register func(blocks []*Block)
+	register = func(blocks []*Block) {
+		for _, v := range blocks {
+			created++
+			v.Meta = &Meta{Object: ObjectTypeBlock, ID: fmt.Sprintf("%08d-0000-0000-0000-000000000000", created)}
+			var children []*Block
+			switch {
+			case v.ColumnList != nil:
+				children = v.ColumnList.Children
+			case v.Column != nil:
+				children = v.Column.Children
+			}
+			register(children)
+			tree[v.ID] = children
+		}
+	}
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/blocks/[a-z0-9-]{36}/children`),
+		func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			id := strings.Split(req.URL.Path, "/")[3]
+			return httpmock.NewJsonResponse(http.StatusOK, &BlockList{ListMeta: &ListMeta{Object: ObjectTypeList}, Results: tree[id]})
+		},
+	)
+	rt.RegisterRegexpResponder(
+		http.MethodPatch,
+		regexp.MustCompile(`/v1/blocks/[a-z0-9-]{36}/children`),
+		func(req *http.Request) (*http.Response, error) {
+			body := struct {
+				Children []*Block `json:"children"`
+			}{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, body.Children)
+			parents = append(parents, strings.Split(req.URL.Path, "/")[3])
+			register(body.Children)
+			return httpmock.NewJsonResponse(http.StatusOK, &BlockList{ListMeta: &ListMeta{Object: ObjectTypeList}, Results: body.Children})
+		},
+	)
+
+	client, err := New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+	require.NoError(t, client.CopyBlocks(context.Background(), "c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1", "d6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1"))
+
+	// The columns and the children of them are created with the column list, and the image is skipped.
+	require.Len(t, requests, 2)
+	require.Len(t, requests[0], 1)
+	columnList := requests[0][0]
+	assert.Equal(t, BlockTypeColumnList, columnList.Type)
+	require.NotNil(t, columnList.ColumnList)
+	require.Len(t, columnList.ColumnList.Children, 2)
+	left := columnList.ColumnList.Children[0]
+	require.NotNil(t, left.Column)
+	require.Len(t, left.Column.Children, 1)
+	assert.Equal(t, "Left", left.Column.Children[0].Paragraph.RichText[0].Text.Content)
+	// The grandchildren of the column are appended to the created block.
+	require.Len(t, requests[1], 1)
+	assert.Equal(t, "Nested", requests[1][0].Paragraph.RichText[0].Text.Content)
+	assert.Equal(t, left.Column.Children[0].ID, parents[1])
+}
+
+func TestUploadFile(t *testing.T) {
+	t.Parallel()
+
+	created, err := os.ReadFile("./testdata/post-file-upload.json")
+	require.NoError(t, err)
+	sent, err := os.ReadFile("./testdata/post-file-upload-send.json")
+	require.NoError(t, err)
+
+	newTransport := func(t *testing.T) (*httpmock.MockTransport, *FileUploadMode, *[]string, *int) {
+		var mode FileUploadMode
+		var parts []string
+		var completed int
+
+		rt := httpmock.NewMockTransport()
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/file_uploads$`),
+			func(req *http.Request) (*http.Response, error) {
+				body := struct {
+					Mode FileUploadMode `json:"mode"`
+				}{}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				mode = body.Mode
+				return httpmock.NewStringResponse(http.StatusOK, string(created)), nil
+			},
+		)
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/file_uploads/[a-z0-9-]{36}/send$`),
+			func(req *http.Request) (*http.Response, error) {
+				if err := req.ParseMultipartForm(32 << 20); err != nil {
+					return nil, err
+				}
+				f, h, err := req.FormFile("file")
+				if err != nil {
+					return nil, err
+				}
+				f.Close()
+				assert.Equal(t, "test.txt", h.Filename)
+				parts = append(parts, req.FormValue("part_number"))
+				return httpmock.NewStringResponse(http.StatusOK, string(sent)), nil
+			},
+		)
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/file_uploads/[a-z0-9-]{36}/complete$`),
+			func(req *http.Request) (*http.Response, error) {
+				completed++
+				return httpmock.NewStringResponse(http.StatusOK, string(sent)), nil
+			},
+		)
+		return rt, &mode, &parts, &completed
+	}
+
+	t.Run("SinglePart", func(t *testing.T) {
+		t.Parallel()
+
+		rt, mode, parts, completed := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		upload, err := client.UploadFile(context.Background(), "test.txt", "text/plain", strings.NewReader("Hello, world"))
+		require.NoError(t, err)
+
+		assert.Equal(t, FileUploadModeSinglePart, *mode)
+		assert.Equal(t, "a3f9d3e2-1abc-42de-b904-badc0ffee000", upload.ID)
+		assert.Equal(t, FileUploadStatusUploaded, upload.Status)
+		assert.Equal(t, []string{""}, *parts)
+		assert.Equal(t, 0, *completed)
+
+		f := upload.File()
+		assert.Equal(t, FileTypeFileUpload, f.Type)
+		assert.Equal(t, "a3f9d3e2-1abc-42de-b904-badc0ffee000", f.FileUpload.ID)
+		b, err := upload.Block(BlockTypeImage)
+		require.NoError(t, err)
+		if assert.NotNil(t, b.Image) {
+			assert.Equal(t, "a3f9d3e2-1abc-42de-b904-badc0ffee000", b.Image.FileUpload.ID)
+		}
+		_, err = upload.Block(BlockTypeParagraph)
+		assert.Error(t, err)
+	})
+
+	t.Run("MultiPart", func(t *testing.T) {
+		t.Parallel()
+
+		rt, mode, parts, completed := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		// The reader which doesn't have the size.
+		r := io.MultiReader(bytes.NewReader(make([]byte, 25*1024*1024)))
+		upload, err := client.UploadFile(context.Background(), "test.txt", "text/plain", r)
+		require.NoError(t, err)
+
+		assert.Equal(t, "a3f9d3e2-1abc-42de-b904-badc0ffee000", upload.ID)
+		assert.Equal(t, FileUploadModeMultiPart, *mode)
+		assert.Equal(t, []string{"1", "2", "3"}, *parts)
+		assert.Equal(t, 1, *completed)
+	})
+}
+
+func TestGetPagesFrom(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/post-database-query.json")
+	require.NoError(t, err)
+	firstPage := withNextCursor(t, res, "cursor-1")
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		var cursors []string
+		rt := httpmock.NewMockTransport()
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/databases/[a-z0-9-]{36}/query`),
+			func(req *http.Request) (*http.Response, error) {
+				body := struct {
+					StartCursor string `json:"start_cursor"`
+				}{}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				cursors = append(cursors, body.StartCursor)
+				if len(cursors) == 1 {
+					return httpmock.NewStringResponse(http.StatusOK, firstPage), nil
+				}
+				return httpmock.NewStringResponse(http.StatusTooManyRequests, `{"object":"error","status":429,"code":"rate_limited","message":"rate limited"}`), nil
+			},
+		)
+
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		pages, err := client.GetPagesFrom(context.Background(), "a4f18e20-365d-4fe1-91e8-080381f877d5", nil, nil, "")
+		assert.Len(t, pages, 1)
+		var pErr *PaginationError
+		if assert.ErrorAs(t, err, &pErr) {
+			assert.Equal(t, "cursor-1", pErr.NextCursor)
+		}
+		var apiErr *Error
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, "rate_limited", apiErr.Code)
+		}
+		assert.Equal(t, []string{"", "cursor-1"}, cursors)
+
+		// GetPages discards the partial results.
+		pages, err = client.GetPages(context.Background(), "a4f18e20-365d-4fe1-91e8-080381f877d5", nil, nil)
+		assert.Nil(t, pages)
+		assert.IsType(t, &Error{}, err)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		rt := httpmock.NewMockTransport()
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/databases/[a-z0-9-]{36}/query`),
+			func(req *http.Request) (*http.Response, error) {
+				cancel()
+				return httpmock.NewStringResponse(http.StatusOK, firstPage), nil
+			},
+		)
+
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		pages, err := client.GetPagesFrom(ctx, "a4f18e20-365d-4fe1-91e8-080381f877d5", nil, nil, "")
+		assert.Len(t, pages, 1)
+		assert.ErrorIs(t, err, context.Canceled)
+		var pErr *PaginationError
+		if assert.ErrorAs(t, err, &pErr) {
+			assert.Equal(t, "cursor-1", pErr.NextCursor)
+		}
+	})
+}
+
+func TestSearchFrom(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/post-search.json")
+	require.NoError(t, err)
+	firstPage := withNextCursor(t, res, "cursor-1")
+
+	calls := 0
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/search$`),
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return httpmock.NewStringResponse(http.StatusOK, firstPage), nil
+			}
+			return httpmock.NewStringResponse(http.StatusBadGateway, `{"object":"error","status":502,"code":"bad_gateway","message":""}`), nil
+		},
+	)
+
+	client, err := New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+
+	results, err := client.SearchFrom(context.Background(), "q", nil, "")
+	assert.Len(t, results, 2)
+	var pErr *PaginationError
+	if assert.ErrorAs(t, err, &pErr) {
+		assert.Equal(t, "cursor-1", pErr.NextCursor)
+	}
+}
+
+func TestMovePage(t *testing.T) {
+	t.Parallel()
+
+	page, err := os.ReadFile("./testdata/get-page.json")
+	require.NoError(t, err)
+	db, err := os.ReadFile("./testdata/get-database.json")
+	require.NoError(t, err)
+	newTransport := func(t *testing.T) (*httpmock.MockTransport, *map[string]interface{}) {
+		var parent map[string]interface{}
+		rt := httpmock.NewMockTransport()
+		rt.RegisterRegexpResponder(
+			http.MethodGet,
+			regexp.MustCompile(`/v1/pages/[a-z0-9-]{36}$`),
+			httpmock.NewStringResponder(http.StatusOK, string(page)),
+		)
+		rt.RegisterRegexpResponder(
+			http.MethodGet,
+			regexp.MustCompile(`/v1/databases/[a-z0-9-]{36}$`),
+			httpmock.NewStringResponder(http.StatusOK, string(db)),
+		)
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/pages/[a-z0-9-]{36}/move$`),
+			func(req *http.Request) (*http.Response, error) {
+				body := struct {
+					Parent map[string]interface{} `json:"parent"`
+				}{}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				parent = body.Parent
+				return httpmock.NewStringResponse(http.StatusOK, string(page)), nil
+			},
+		)
+		return rt, &parent
+	}
+	pageID := "16493215-50a8-41b8-8b43-0a0c014a7910"
+
+	t.Run("Page", func(t *testing.T) {
+		t.Parallel()
+
+		rt, parent := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		moved, err := client.MovePage(context.Background(), pageID, &PageParent{PageID: "c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1"})
+		require.NoError(t, err)
+		assert.Equal(t, pageID, moved.ID)
+		assert.Equal(t, map[string]interface{}{"type": "page_id", "page_id": "c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1"}, *parent)
+		assert.Equal(t, 1, rt.GetTotalCallCount())
+	})
+
+	t.Run("Workspace", func(t *testing.T) {
+		t.Parallel()
+
+		rt, parent := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		_, err = client.MovePage(context.Background(), pageID, &PageParent{Workspace: true})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"type": "workspace", "workspace": true}, *parent)
+	})
+
+	t.Run("Database", func(t *testing.T) {
+		t.Parallel()
+
+		rt, parent := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		_, err = client.MovePage(context.Background(), pageID, &PageParent{DatabaseID: "ba8e1263-af24-4cd0-87e0-6e2933303b60"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"type": "database_id", "database_id": "ba8e1263-af24-4cd0-87e0-6e2933303b60"}, *parent)
+		assert.Equal(t, 3, rt.GetTotalCallCount())
+	})
+
+	t.Run("SchemaMismatch", func(t *testing.T) {
+		t.Parallel()
+
+		rt, parent := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		target := &Database{
+			Meta: &Meta{ID: "1d4c8a0e-0c5e-4e4b-a3b4-8f1e1c3d5b71"},
+			Properties: map[string]*PropertyMetadata{
+				"Title": {Type: PropertyTypeTitle},
+				"Test1": {Type: PropertyTypeNumber},
+			},
+		}
+		_, err = client.MovePage(context.Background(), pageID, &PageParent{DatabaseID: target.ID, Database: target})
+		require.ErrorIs(t, err, ErrSchemaMismatch)
+		assert.Equal(t, "notion: schema mismatch: Test1 is number, not rich_text", err.Error())
+
+		delete(target.Properties, "Test1")
+		_, err = client.MovePage(context.Background(), pageID, &PageParent{DatabaseID: target.ID, Database: target})
+		require.ErrorIs(t, err, ErrSchemaMismatch)
+		assert.Equal(t, "notion: schema mismatch: Test1 is not found", err.Error())
+		assert.Nil(t, *parent)
+	})
+
+	t.Run("NoParent", func(t *testing.T) {
+		t.Parallel()
+
+		rt, _ := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		_, err = client.MovePage(context.Background(), pageID, nil)
+		assert.Error(t, err)
+		_, err = client.MovePage(context.Background(), pageID, &PageParent{})
+		assert.Error(t, err)
+		assert.Equal(t, 0, rt.GetTotalCallCount())
+	})
+}
+
+func TestUpsertPage(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/post-database-query.json")
+	require.NoError(t, err)
+	list := make(map[string]any)
+	require.NoError(t, json.Unmarshal(res, &list))
+	page := list["results"].([]any)[0]
+	postPage, err := os.ReadFile("./testdata/post-page.json")
+	require.NoError(t, err)
+	patchPage, err := os.ReadFile("./testdata/patch-page.json")
+	require.NoError(t, err)
+
+	newTransport := func(t *testing.T) (*httpmock.MockTransport, *Filter, *Page, *bool) {
+		filter := &Filter{}
+		created := &Page{}
+		updated := false
+		rt := httpmock.NewMockTransport()
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/databases/[a-z0-9-]{36}/query$`),
+			func(req *http.Request) (*http.Response, error) {
+				body := struct {
+					Filter *Filter `json:"filter"`
+				}{Filter: filter}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				var results []any
+				switch {
+				case filter.Title != nil && filter.Title.Equals == "Foo":
+					results = []any{page}
+				case filter.RichText != nil && filter.RichText.Equals == "dup":
+					results = []any{page, page}
+				case filter.UniqueID != nil && filter.UniqueID.Equals == 15:
+					results = []any{page}
+				}
+				list := map[string]any{"object": "list", "results": results, "has_more": false}
+				return httpmock.NewJsonResponse(http.StatusOK, list)
+			},
+		)
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/pages$`),
+			func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(created); err != nil {
+					return nil, err
+				}
+				return httpmock.NewStringResponse(http.StatusOK, string(postPage)), nil
+			},
+		)
+		rt.RegisterRegexpResponder(
+			http.MethodPatch,
+			regexp.MustCompile(`/v1/pages/[a-z0-9-]{36}$`),
+			func(req *http.Request) (*http.Response, error) {
+				updated = true
+				assert.Equal(t, "/v1/pages/16493215-50a8-41b8-8b43-0a0c014a7910", req.URL.Path)
+				return httpmock.NewStringResponse(http.StatusOK, string(patchPage)), nil
+			},
+		)
+		return rt, filter, created, &updated
+	}
+	db := &Database{
+		Meta: &Meta{ID: "ba8e1263-af24-4cd0-87e0-6e2933303b60"},
+		Properties: map[string]*PropertyMetadata{
+			"Name":  {ID: "title", Type: PropertyTypeTitle},
+			"Test1": {ID: "Test1", Type: PropertyTypeRichText},
+			"ID":    {ID: "ID", Type: PropertyTypeUniqueID, UniqueID: &UniqueIDProperty{Prefix: "TEST"}},
+			"Test8": {ID: "Test8", Type: PropertyTypeCheckbox},
+		},
+	}
+	properties := map[string]*PropertyData{
+		"Test8": {Type: PropertyTypeCheckbox, Checkbox: true},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		t.Parallel()
+
+		rt, filter, created, updated := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		page, err := client.UpsertPage(context.Background(), db, "Name", "Bar", properties)
+		require.NoError(t, err)
+		assert.Equal(t, "9585d9b5-ad82-4221-9f82-a3a4767d5b92", page.ID)
+		assert.Equal(t, "Name", filter.Property)
+		assert.False(t, *updated)
+		if assert.NotNil(t, created.Parent) {
+			assert.Equal(t, "ba8e1263-af24-4cd0-87e0-6e2933303b60", created.Parent.DatabaseID)
+		}
+		if assert.Contains(t, created.Properties, "Name") {
+			assert.Equal(t, "Bar", created.Properties["Name"].Title[0].Text.Content)
+		}
+		assert.Contains(t, created.Properties, "Test8")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		t.Parallel()
+
+		rt, _, created, updated := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		page, err := client.UpsertPage(context.Background(), db, "Name", "Foo", properties)
+		require.NoError(t, err)
+		assert.Equal(t, "9585d9b5-ad82-4221-9f82-a3a4767d5b92", page.ID)
+		assert.True(t, *updated)
+		assert.Nil(t, created.Properties)
+	})
+
+	t.Run("UniqueID", func(t *testing.T) {
+		t.Parallel()
+
+		rt, filter, _, updated := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		_, err = client.UpsertPage(context.Background(), db, "ID", "TEST-15", properties)
+		require.NoError(t, err)
+		assert.True(t, *updated)
+		if assert.NotNil(t, filter.UniqueID) {
+			assert.Equal(t, 15, filter.UniqueID.Equals)
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		t.Parallel()
+
+		rt, _, _, updated := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		_, err = client.UpsertPage(context.Background(), db, "Test1", "dup", properties)
+		assert.ErrorIs(t, err, ErrDuplicateKey)
+		assert.False(t, *updated)
+	})
+
+	t.Run("UniqueIDNotFound", func(t *testing.T) {
+		t.Parallel()
+
+		rt, _, created, updated := newTransport(t)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com")
+		require.NoError(t, err)
+
+		_, err = client.UpsertPage(context.Background(), db, "ID", "TEST-16", properties)
+		assert.ErrorIs(t, err, ErrPageNotFound)
+		assert.False(t, *updated)
+		assert.Nil(t, created.Properties)
+	})
+
+	t.Run("DataSource", func(t *testing.T) {
+		t.Parallel()
+
+		ds, err := os.ReadFile("./testdata/get-data-source.json")
+		require.NoError(t, err)
+		rt := httpmock.NewMockTransport()
+		rt.RegisterRegexpResponder(
+			http.MethodGet,
+			regexp.MustCompile(`/v1/data_sources/248104cd-477e-80af-bc30-000bd28de8f9$`),
+			httpmock.NewStringResponder(http.StatusOK, string(ds)),
+		)
+		filter := &Filter{}
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/data_sources/248104cd-477e-80af-bc30-000bd28de8f9/query$`),
+			func(req *http.Request) (*http.Response, error) {
+				body := struct {
+					Filter *Filter `json:"filter"`
+				}{Filter: filter}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				return httpmock.NewJsonResponse(http.StatusOK, map[string]any{"object": "list", "results": []any{}, "has_more": false})
+			},
+		)
+		created := &Page{}
+		rt.RegisterRegexpResponder(
+			http.MethodPost,
+			regexp.MustCompile(`/v1/pages$`),
+			func(req *http.Request) (*http.Response, error) {
+				if err := json.NewDecoder(req.Body).Decode(created); err != nil {
+					return nil, err
+				}
+				return httpmock.NewStringResponse(http.StatusOK, string(postPage)), nil
+			},
+		)
+		client, err := New(&http.Client{Transport: rt}, "https://example.com", WithVersion(Version20250903))
+		require.NoError(t, err)
+
+		// The properties of the database are empty since 2025-09-03. The schema is read from the data source.
+		db := &Database{
+			Meta:        &Meta{ID: "ba8e1263-af24-4cd0-87e0-6e2933303b60"},
+			DataSources: []*DataSourceReference{{ID: "248104cd-477e-80af-bc30-000bd28de8f9"}},
+		}
+		_, err = client.UpsertPage(context.Background(), db, "Test1", "Bar", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Test1", filter.Property)
+		if assert.NotNil(t, created.Parent) {
+			assert.Equal(t, ObjectTypeDataSourceID, created.Parent.Type)
+			assert.Equal(t, "248104cd-477e-80af-bc30-000bd28de8f9", created.Parent.DataSourceID)
+			assert.Empty(t, created.Parent.DatabaseID)
+		}
+		assert.Contains(t, created.Properties, "Test1")
+
+		db.DataSources = append(db.DataSources, &DataSourceReference{ID: "3c1a2f5e-6b4d-4f1e-9a2b-7c8d9e0f1a2b"})
+		_, err = client.UpsertPage(context.Background(), db, "Test1", "Bar", nil)
+		assert.ErrorIs(t, err, ErrMultipleDataSources)
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := New(&http.Client{Transport: httpmock.NewMockTransport()}, "https://example.com")
+		require.NoError(t, err)
+
+		_, err = client.UpsertPage(context.Background(), db, "Test8", "true", properties)
+		assert.Error(t, err)
+		_, err = client.UpsertPage(context.Background(), db, "Unknown", "foo", properties)
+		assert.Error(t, err)
+		// The empty key matches any page.
+		_, err = client.UpsertPage(context.Background(), db, "Name", "", properties)
+		assert.Error(t, err)
+		_, err = client.UpsertPage(context.Background(), db, "ID", "TEST-0", properties)
+		assert.Error(t, err)
+		// The prefix is different from the schema.
+		_, err = client.UpsertPage(context.Background(), db, "ID", "BUG-15", properties)
+		assert.ErrorContains(t, err, "prefix")
+	})
+}
+
+func withNextCursor(t *testing.T, res []byte, cursor string) string {
+	obj := make(map[string]any)
+	require.NoError(t, json.Unmarshal(res, &obj))
+	obj["has_more"] = true
+	obj["next_cursor"] = cursor
+	buf, err := json.Marshal(obj)
+	require.NoError(t, err)
+
+	return string(buf)
+}
+
 func mockTransport(t *testing.T, method, pathRegex string, status int, responseFile string) *httpmock.MockTransport {
 	rt := httpmock.NewMockTransport()
 	res, err := os.ReadFile(responseFile)
@@ -1069,3 +2203,55 @@ func mockTransport(t *testing.T, method, pathRegex string, status int, responseF
 func ptr[T any](in T) *T {
 	return &in
 }
+
+func TestRefreshFiles(t *testing.T) {
+	t.Parallel()
+
+	expired := &Time{Time: time.Now().Add(-time.Minute)}
+	fresh := &Time{Time: time.Now().Add(time.Hour)}
+	imageBlock := func(url string, expiry *Time) *Block {
+		return &Block{
+			Meta:  &Meta{Object: ObjectTypeBlock, ID: "6fbac55c-9e74-4489-b386-0c88d5aa54dd"},
+			Type:  BlockTypeImage,
+			Image: &FileBlock{Type: FileTypeFile, File: &NotionFile{URL: url, ExpiryTime: expiry}},
+		}
+	}
+	filesPage := func(url string, expiry *Time) *Page {
+		return &Page{
+			Meta: &Meta{Object: ObjectTypePage, ID: "56f2049d-feb1-4a3f-b227-2fa76ca74d0e"},
+			Properties: map[string]*PropertyData{
+				"Attachments": {Type: PropertyTypeFiles, Files: []*File{{Type: FileTypeFile, Name: "a.pdf", File: &NotionFile{URL: url, ExpiryTime: expiry}}}},
+			},
+		}
+	}
+
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/blocks/[a-z0-9-]{36}$`),
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, imageBlock("https://example.com/new.png", fresh)),
+	)
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/pages/[a-z0-9-]{36}$`),
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, filesPage("https://example.com/new.pdf", fresh)),
+	)
+	client, err := New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+
+	block, err := client.RefreshBlock(context.Background(), imageBlock("https://example.com/old.png", fresh))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/old.png", block.FileBlock().URL())
+	block, err = client.RefreshBlock(context.Background(), imageBlock("https://example.com/old.png", expired))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/new.png", block.FileBlock().URL())
+
+	page, err := client.RefreshPage(context.Background(), filesPage("https://example.com/old.pdf", fresh))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/old.pdf", page.Properties["Attachments"].Files[0].URL())
+	page, err = client.RefreshPage(context.Background(), filesPage("https://example.com/old.pdf", expired))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/new.pdf", page.Properties["Attachments"].Files[0].URL())
+
+	assert.Equal(t, 2, rt.GetTotalCallCount())
+}
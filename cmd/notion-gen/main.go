@@ -0,0 +1,68 @@
+// notion-gen generates the typed model of the database.
+//
+//	notion-gen -database DATABASE_ID -package tasks -type Task -out task.go
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"golang.org/x/oauth2"
+
+	"go.f110.dev/notion-api/v3"
+	"go.f110.dev/notion-api/v3/gen"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	var token, databaseID, out string
+	var opts gen.Options
+	fs := flag.NewFlagSet("notion-gen", flag.ContinueOnError)
+	fs.StringVar(&token, "token", os.Getenv("NOTION_TOKEN"), "API Token. The default value is $NOTION_TOKEN")
+	fs.StringVar(&databaseID, "database", "", "Database ID")
+	fs.StringVar(&opts.Package, "package", "", "The package name of the generated code")
+	fs.StringVar(&opts.TypeName, "type", "", "The name of the struct. The default value is made from the title of the database")
+	fs.StringVar(&out, "out", "", "The output file. The generated code is written to stdout if not specified")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if token == "" {
+		return errors.New("-token or NOTION_TOKEN is required")
+	}
+	if databaseID == "" {
+		return errors.New("-database is required")
+	}
+	if opts.Package == "" {
+		return errors.New("-package is required")
+	}
+
+	ctx := context.Background()
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	client, err := notion.New(oauth2.NewClient(ctx, ts), notion.BaseURL)
+	if err != nil {
+		return err
+	}
+	db, err := client.GetDatabase(ctx, databaseID)
+	if err != nil {
+		return err
+	}
+	src, err := gen.Generate(db, opts)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0644)
+}
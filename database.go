@@ -6,14 +6,7 @@ func NewDatabase(parent *Page, title string) *Database {
 			Type:   "page_id",
 			PageID: parent.ID,
 		},
-		Title: []*RichTextObject{
-			{
-				Type: "text",
-				Text: &Text{
-					Content: title,
-				},
-			},
-		},
+		Title:      RichText().Text(title).Build(),
 		Properties: make(map[string]*PropertyMetadata),
 	}
 }
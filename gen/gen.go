@@ -0,0 +1,389 @@
+// Package gen generates the Go code of the typed model of the database.
+// The generated code has the struct which is mapped by notion.UnmarshalPage and notion.MarshalProperties,
+// the constants of the options, the filter constructors and the CRUD helpers.
+// Thus the change of the schema surfaces as the compile error.
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.f110.dev/notion-api/v3"
+)
+
+type Options struct {
+	// Package is the name of the package of the generated code.
+	Package string
+	// TypeName is the name of the struct. If TypeName is empty, the name is made from the title of the database.
+	TypeName string
+}
+
+type field struct {
+	Name     string
+	Property string
+	Type     notion.PropertyType
+	GoType   string
+	// OptionType is the name of the type of the options. OptionType is empty if the property has no option.
+	OptionType string
+	Options    []*option
+	Filters    []*filter
+}
+
+type option struct {
+	Const string
+	Name  string
+}
+
+type filter struct {
+	Func string
+	Arg  string
+	// Body is the fields of notion.Filter except Property.
+	Body string
+}
+
+// names allocates the unique identifiers.
+type names map[string]struct{}
+
+func newNames(reserved ...string) names {
+	n := make(names)
+	for _, v := range reserved {
+		n[v] = struct{}{}
+	}
+	return n
+}
+
+// unique returns name. If name is already used, the number is appended.
+func (n names) unique(name string) string {
+	id := name
+	for i := 2; ; i++ {
+		if _, ok := n[id]; !ok {
+			n[id] = struct{}{}
+			return id
+		}
+		id = fmt.Sprintf("%s%d", name, i)
+	}
+}
+
+// Generate returns the formatted Go code of the model of db.
+func Generate(db *notion.Database, opts Options) ([]byte, error) {
+	if db.Meta == nil || db.ID == "" {
+		return nil, errors.New("gen: the database doesn't have ID")
+	}
+	if opts.Package == "" {
+		return nil, errors.New("gen: the package name is not specified")
+	}
+	typeName := opts.TypeName
+	if typeName == "" {
+		var title strings.Builder
+		for _, v := range db.Title {
+			title.WriteString(v.PlainText)
+		}
+		typeName = identifier(title.String(), "Model")
+	}
+
+	fields, unsupported := buildFields(typeName, db.Properties)
+
+	w := &writer{}
+	w.P("// Code generated by notion-gen. DO NOT EDIT.")
+	w.P("")
+	w.P("package %s", opts.Package)
+	w.P("")
+	w.P("import (")
+	w.P(`"context"`)
+	w.P("")
+	w.P(`"go.f110.dev/notion-api/v3"`)
+	w.P(")")
+	w.P("")
+	w.P("// %sDatabaseID is the ID of the database.", typeName)
+	w.P("const %sDatabaseID = %q", typeName, db.ID)
+	w.P("")
+
+	w.P("// %s is the page of the database.", typeName)
+	w.P("type %s struct {", typeName)
+	w.P("// ID is the ID of the page. ID is empty until the page is created.")
+	w.P("ID string")
+	w.P("")
+	for _, f := range fields {
+		w.P("%s %s %s", f.Name, f.GoType, structTag(f))
+	}
+	if len(unsupported) > 0 {
+		w.P("")
+		w.P("// The following properties are not supported.")
+		for _, v := range unsupported {
+			w.P("// %q (%s)", v.Property, v.Type)
+		}
+	}
+	w.P("}")
+
+	for _, f := range fields {
+		if f.OptionType == "" {
+			continue
+		}
+		w.P("")
+		w.P("// %s is the option of %q.", f.OptionType, f.Property)
+		w.P("type %s string", f.OptionType)
+		if len(f.Options) > 0 {
+			w.P("")
+			w.P("const (")
+			for _, o := range f.Options {
+				w.P("%s %s = %q", o.Const, f.OptionType, o.Name)
+			}
+			w.P(")")
+		}
+	}
+
+	for _, f := range fields {
+		for _, v := range f.Filters {
+			w.P("")
+			w.P("// %s returns the filter of %q.", v.Func, f.Property)
+			w.P("func %s(%s) *notion.Filter {", v.Func, v.Arg)
+			w.P("return &notion.Filter{Property: %q, %s}", f.Property, v.Body)
+			w.P("}")
+		}
+	}
+	writeHelpers(w, typeName)
+
+	src, err := format.Source(w.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("gen: failed to format the generated code: %v", err)
+	}
+	return src, nil
+}
+
+func buildFields(typeName string, properties map[string]*notion.PropertyMetadata) ([]*field, []*field) {
+	names := make([]string, 0, len(properties))
+	for k := range properties {
+		names = append(names, k)
+	}
+	// The title is the first field, and the others are sorted by the name.
+	sort.Slice(names, func(i, j int) bool {
+		ti, tj := properties[names[i]].Type == notion.PropertyTypeTitle, properties[names[j]].Type == notion.PropertyTypeTitle
+		if ti != tj {
+			return ti
+		}
+		return names[i] < names[j]
+	})
+
+	// The names of the fields of the struct and the names of the top-level declarations are allocated separately.
+	fieldNames := newNames("ID")
+	decls := newNames(typeName, typeName+"DatabaseID", "Get"+typeName, "Query"+typeName, "Create"+typeName, "Update"+typeName, "new"+typeName)
+
+	var fields, unsupported []*field
+	for _, name := range names {
+		meta := properties[name]
+		f := &field{Property: name, Type: meta.Type}
+		switch meta.Type {
+		case notion.PropertyTypeTitle, notion.PropertyTypeRichText, notion.PropertyTypeURL, notion.PropertyTypeEmail, notion.PropertyTypePhoneNumber:
+			f.GoType = "string"
+		case notion.PropertyTypeNumber:
+			// The number property may be empty.
+			f.GoType = "*float64"
+		case notion.PropertyTypeCheckbox:
+			f.GoType = "bool"
+		case notion.PropertyTypeDate:
+			f.GoType = "*notion.DateProperty"
+		case notion.PropertyTypePeople, notion.PropertyTypeRelation:
+			f.GoType = "[]string"
+		case notion.PropertyTypeSelect, notion.PropertyTypeMultiSelect, notion.PropertyTypeStatus:
+			f.OptionType = decls.unique(typeName + identifier(name, "Property"))
+			f.GoType = f.OptionType
+			if meta.Type == notion.PropertyTypeMultiSelect {
+				f.GoType = "[]" + f.OptionType
+			}
+		default:
+			unsupported = append(unsupported, f)
+			continue
+		}
+		f.Name = fieldNames.unique(identifier(name, "Property"))
+		fields = append(fields, f)
+	}
+
+	// The filters are named before the constants of the options so that the names of the filters are stable.
+	for _, f := range fields {
+		f.Filters = filters(f)
+		for _, v := range f.Filters {
+			v.Func = decls.unique(typeName + f.Name + v.Func)
+		}
+	}
+	for _, f := range fields {
+		if f.OptionType == "" {
+			continue
+		}
+		var opts []*notion.Option
+		switch meta := properties[f.Property]; {
+		case meta.Select != nil:
+			opts = meta.Select.Options
+		case meta.MultiSelect != nil:
+			opts = meta.MultiSelect.Options
+		case meta.Status != nil:
+			opts = meta.Status.Options
+		}
+		for _, o := range opts {
+			f.Options = append(f.Options, &option{Const: decls.unique(f.OptionType + identifier(o.Name, "Option")), Name: o.Name})
+		}
+	}
+
+	return fields, unsupported
+}
+
+// structTag returns the struct tag of the field.
+// The name of the property is quoted because it may have any character (e.g. the double quote and the back quote).
+// The type is always specified because the name may have the comma.
+func structTag(f *field) string {
+	tag := "notion:" + strconv.Quote(f.Property+","+string(f.Type))
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+// filters returns the filters of the field. Func of the filter is the suffix of the name of the function.
+func filters(f *field) []*filter {
+	var list []*filter
+	add := func(name, arg, body string) {
+		list = append(list, &filter{Func: name, Arg: arg, Body: body})
+	}
+
+	switch f.Type {
+	case notion.PropertyTypeTitle:
+		add("Equals", "v string", "Title: &notion.RichTextFilter{Equals: v}")
+		add("Contains", "v string", "Title: &notion.RichTextFilter{Contains: v}")
+	case notion.PropertyTypeRichText:
+		add("Equals", "v string", "RichText: &notion.RichTextFilter{Equals: v}")
+		add("Contains", "v string", "RichText: &notion.RichTextFilter{Contains: v}")
+	case notion.PropertyTypeNumber:
+		add("Equals", "v float64", "Number: &notion.NumberFilter{Equals: &v}")
+		add("GreaterThan", "v float64", "Number: &notion.NumberFilter{GreaterThan: &v}")
+		add("LessThan", "v float64", "Number: &notion.NumberFilter{LessThan: &v}")
+	case notion.PropertyTypeCheckbox:
+		add("Equals", "v bool", "Checkbox: &notion.CheckboxFilter{Equals: v}")
+	case notion.PropertyTypeSelect:
+		add("Equals", "v "+f.OptionType, "Select: &notion.SelectFilter{Equals: string(v)}")
+	case notion.PropertyTypeStatus:
+		add("Equals", "v "+f.OptionType, "Status: &notion.StatusFilter{Equals: string(v)}")
+	case notion.PropertyTypeMultiSelect:
+		add("Contains", "v "+f.OptionType, "MultiSelect: &notion.MultiSelectFilter{Contains: string(v)}")
+	case notion.PropertyTypeDate:
+		add("Before", "v *notion.Date", "Date: &notion.DateFilter{Before: v}")
+		add("After", "v *notion.Date", "Date: &notion.DateFilter{After: v}")
+		add("OnOrAfter", "v *notion.Date", "Date: &notion.DateFilter{OnOrAfter: v}")
+		add("OnOrBefore", "v *notion.Date", "Date: &notion.DateFilter{OnOrBefore: v}")
+	case notion.PropertyTypePeople:
+		add("Contains", "userID string", "People: &notion.PeopleFilter{Contains: userID}")
+	case notion.PropertyTypeRelation:
+		add("Contains", "pageID string", "Relation: &notion.RelationFilter{Contains: pageID}")
+	}
+	return list
+}
+
+func writeHelpers(w *writer, typeName string) {
+	w.P(`
+// Get%[1]s returns the page of the database.
+func Get%[1]s(ctx context.Context, client *notion.Client, pageID string) (*%[1]s, error) {
+	page, err := client.GetPage(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+	return new%[1]s(page)
+}
+
+// Query%[1]s returns the pages which match the filter.
+// Since 2025-09-03, the data source of the database is queried.
+func Query%[1]s(ctx context.Context, client *notion.Client, filter *notion.Filter, sorts []*notion.Sort) ([]*%[1]s, error) {
+	pages, err := client.QueryDatabase(ctx, %[1]sDatabaseID, filter, sorts)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*%[1]s, 0, len(pages))
+	for _, page := range pages {
+		v, err := new%[1]s(page)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+// Create%[1]s creates the page in the database.
+// Since 2025-09-03, the page is created in the data source of the database.
+func Create%[1]s(ctx context.Context, client *notion.Client, v *%[1]s) (*%[1]s, error) {
+	properties, err := notion.MarshalProperties(v, nil)
+	if err != nil {
+		return nil, err
+	}
+	page, err := client.CreatePage(ctx, &notion.Page{
+		Parent:     &notion.PageParent{Type: notion.ObjectTypeDatabaseID, DatabaseID: %[1]sDatabaseID},
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return new%[1]s(page)
+}
+
+// Update%[1]s updates the properties of the page.
+// The fields which have the empty value (e.g. the empty string and nil) are not updated, so Update%[1]s can't clear the property.
+func Update%[1]s(ctx context.Context, client *notion.Client, v *%[1]s) (*%[1]s, error) {
+	properties, err := notion.MarshalProperties(v, nil)
+	if err != nil {
+		return nil, err
+	}
+	page, err := client.UpdateProperties(ctx, v.ID, properties)
+	if err != nil {
+		return nil, err
+	}
+	return new%[1]s(page)
+}
+
+func new%[1]s(page *notion.Page) (*%[1]s, error) {
+	v := &%[1]s{ID: page.ID}
+	if err := notion.UnmarshalPage(page, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}`, typeName)
+}
+
+// identifier returns the exported Go identifier from s.
+// If s doesn't have any letter or digit, identifier returns fallback.
+func identifier(s, fallback string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	id := b.String()
+	if id == "" {
+		return fallback
+	}
+	// The identifier has to start with the upper case letter to be exported.
+	if first := []rune(id)[0]; !unicode.IsUpper(first) {
+		id = fallback + id
+	}
+	return id
+}
+
+type writer struct {
+	bytes.Buffer
+}
+
+// P writes the formatted line.
+func (w *writer) P(format string, args ...interface{}) {
+	fmt.Fprintf(&w.Buffer, format, args...)
+	w.WriteByte('\n')
+}
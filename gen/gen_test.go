@@ -0,0 +1,161 @@
+package gen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/notion-api/v3"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	db := &notion.Database{
+		Meta:  &notion.Meta{ID: "7b7e6c6e-4c4b-4d49-9e2a-7b0d2c1b8f10"},
+		Title: []*notion.RichTextObject{{PlainText: "my tasks"}},
+		Properties: map[string]*notion.PropertyMetadata{
+			"Name":     {Type: notion.PropertyTypeTitle},
+			"Note":     {Type: notion.PropertyTypeRichText},
+			"Estimate": {Type: notion.PropertyTypeNumber},
+			"Done":     {Type: notion.PropertyTypeCheckbox},
+			"Due date": {Type: notion.PropertyTypeDate},
+			"Assignee": {Type: notion.PropertyTypePeople},
+			"Parent":   {Type: notion.PropertyTypeRelation},
+			"Website":  {Type: notion.PropertyTypeURL},
+			"Priority": {
+				Type:   notion.PropertyTypeSelect,
+				Select: &notion.SelectProperty{Options: []*notion.Option{{Name: "High"}, {Name: "Low"}}},
+			},
+			"Tags": {
+				Type:        notion.PropertyTypeMultiSelect,
+				MultiSelect: &notion.MultiSelectProperty{Options: []*notion.Option{{Name: "bug"}, {Name: "1st"}}},
+			},
+			"Status": {
+				Type:   notion.PropertyTypeStatus,
+				Status: &notion.StatusProperty{Options: []*notion.Option{{Name: "Not started"}, {Name: "In progress"}}},
+			},
+			"Total": {Type: notion.PropertyTypeFormula},
+		},
+	}
+
+	src, err := Generate(db, Options{Package: "tasks"})
+	require.NoError(t, err)
+	typeCheck(t, src)
+
+	s := string(src)
+	assert.Contains(t, s, "// Code generated by notion-gen. DO NOT EDIT.")
+	assert.Contains(t, s, `const MyTasksDatabaseID = "7b7e6c6e-4c4b-4d49-9e2a-7b0d2c1b8f10"`)
+	assert.Regexp(t, "Name +string +`notion:\"Name,title\"`", s)
+	assert.Regexp(t, "Estimate +\\*float64 +`notion:\"Estimate,number\"`", s)
+	assert.Regexp(t, "DueDate +\\*notion.DateProperty +`notion:\"Due date,date\"`", s)
+	assert.Regexp(t, "Tags +\\[\\]MyTasksTags +`notion:\"Tags,multi_select\"`", s)
+	assert.Contains(t, s, `// "Total" (formula)`)
+	assert.Contains(t, s, `MyTasksStatusInProgress MyTasksStatus = "In progress"`)
+	assert.Contains(t, s, `MyTasksTagsOption1st MyTasksTags = "1st"`)
+	assert.Contains(t, s, "func MyTasksPriorityEquals(v MyTasksPriority) *notion.Filter")
+	assert.Contains(t, s, "func MyTasksEstimateGreaterThan(v float64) *notion.Filter")
+	assert.Contains(t, s, "Number: &notion.NumberFilter{GreaterThan: &v}")
+	assert.NotContains(t, s, "MyTasksWebsiteEquals")
+	// GetPages is not available since 2025-09-03.
+	assert.Contains(t, s, "client.QueryDatabase(ctx, MyTasksDatabaseID, filter, sorts)")
+	for _, v := range []string{"GetMyTasks", "QueryMyTasks", "CreateMyTasks", "UpdateMyTasks"} {
+		assert.Contains(t, s, "func "+v+"(")
+	}
+
+	_, err = Generate(db, Options{})
+	assert.Error(t, err)
+}
+
+func TestGenerate_Conflict(t *testing.T) {
+	t.Parallel()
+
+	db := &notion.Database{
+		Meta:  &notion.Meta{ID: "7b7e6c6e-4c4b-4d49-9e2a-7b0d2c1b8f10"},
+		Title: []*notion.RichTextObject{{PlainText: "tasks"}},
+		Properties: map[string]*notion.PropertyMetadata{
+			"Name":      {Type: notion.PropertyTypeTitle},
+			`Say "hi"`:  {Type: notion.PropertyTypeRichText},
+			"Back`tick": {Type: notion.PropertyTypeURL},
+			"Cost, USD": {Type: notion.PropertyTypeNumber},
+			"Database ID": {
+				Type:   notion.PropertyTypeSelect,
+				Select: &notion.SelectProperty{Options: []*notion.Option{{Name: "a"}}},
+			},
+			"Priority": {
+				Type:   notion.PropertyTypeSelect,
+				Select: &notion.SelectProperty{Options: []*notion.Option{{Name: "Equals"}, {Name: "equals"}}},
+			},
+		},
+	}
+	src, err := Generate(db, Options{Package: "tasks"})
+	require.NoError(t, err)
+	pkg := typeCheck(t, src)
+
+	// The tags have the names of the properties as is.
+	st := pkg.Scope().Lookup("Tasks").Type().Underlying().(*types.Struct)
+	tags := make(map[string]string)
+	for i := 0; i < st.NumFields(); i++ {
+		if v, ok := reflect.StructTag(st.Tag(i)).Lookup("notion"); ok {
+			tags[st.Field(i).Name()] = v
+		}
+	}
+	assert.Equal(t, `Say "hi",rich_text`, tags["SayHi"])
+	assert.Equal(t, "Back`tick,url", tags["BackTick"])
+	assert.Equal(t, "Cost, USD,number", tags["CostUSD"])
+
+	s := string(src)
+	assert.Contains(t, s, `const TasksDatabaseID = "7b7e6c6e-4c4b-4d49-9e2a-7b0d2c1b8f10"`)
+	assert.Contains(t, s, "type TasksDatabaseID2 string")
+	assert.Contains(t, s, "func TasksPriorityEquals(v TasksPriority) *notion.Filter")
+	assert.Contains(t, s, `TasksPriorityEquals2 TasksPriority = "Equals"`)
+	assert.Contains(t, s, `TasksPriorityEquals3 TasksPriority = "equals"`)
+}
+
+// sourceImporter imports the packages from the source. The importer is shared because importing notion package is slow.
+// The importer is not safe for concurrent use, so typeCheck holds the lock.
+var (
+	sourceMu       sync.Mutex
+	sourceFset     = token.NewFileSet()
+	sourceImporter = importer.ForCompiler(sourceFset, "source", nil)
+)
+
+// typeCheck checks that the generated code can be compiled with the current API of notion package.
+func typeCheck(t *testing.T, src []byte) *types.Package {
+	t.Helper()
+	sourceMu.Lock()
+	defer sourceMu.Unlock()
+
+	file, err := parser.ParseFile(sourceFset, "tasks.go", src, parser.AllErrors)
+	require.NoError(t, err)
+	conf := &types.Config{Importer: sourceImporter}
+	pkg, err := conf.Check("tasks", sourceFset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+	return pkg
+}
+
+func TestIdentifier(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		In   string
+		Want string
+	}{
+		{In: "due date", Want: "DueDate"},
+		{In: "In-Progress", Want: "InProgress"},
+		{In: "2nd", Want: "Property2nd"},
+		{In: "!!", Want: "Property"},
+		{In: "日本語", Want: "Property日本語"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.Want, identifier(tc.In, "Property"), tc.In)
+	}
+}
@@ -0,0 +1,519 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Iterator iterates the results of the list endpoint.
+// The response is decoded by streaming so that Iterator holds only one result at a time.
+// Iterator fetches the next page of the results automatically.
+//
+//	it := client.IteratePages(ctx, databaseID, nil, nil)
+//	defer it.Close()
+//	for it.Next() {
+//		page := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	ctx    context.Context
+	fetch  func(ctx context.Context, startCursor string) (*http.Response, error)
+	decode func(d *listDecoder) (T, error)
+
+	res    *http.Response
+	dec    *listDecoder
+	cursor string
+	cur    T
+	err    error
+	done   bool
+}
+
+func newIterator[T any](ctx context.Context, startCursor string, fetch func(context.Context, string) (*http.Response, error), decode func(*listDecoder) (T, error)) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, cursor: startCursor, fetch: fetch, decode: decode}
+}
+
+// Next advances the iterator to the next result.
+// Next returns false when the iteration is finished or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	for {
+		if it.err != nil || it.done {
+			return false
+		}
+
+		if it.dec == nil {
+			if err := it.ctx.Err(); err != nil {
+				it.err = err
+				return false
+			}
+			res, err := it.fetch(it.ctx, it.cursor)
+			if err != nil {
+				it.err = err
+				return false
+			}
+			it.res = res
+			it.dec = newListDecoder(res.Body)
+			if err := it.dec.start(); err != nil {
+				it.fail(err)
+				return false
+			}
+		}
+
+		if it.dec.More() {
+			v, err := it.decode(it.dec)
+			if err != nil {
+				it.fail(err)
+				return false
+			}
+			it.cur = v
+			return true
+		}
+
+		meta, err := it.dec.finish()
+		if err != nil {
+			it.fail(err)
+			return false
+		}
+		it.res.Body.Close()
+		it.res, it.dec = nil, nil
+		if !meta.HasMore {
+			it.done = true
+			return false
+		}
+		it.cursor = meta.NextCursor
+	}
+}
+
+// Value returns the current result.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the error which occurred during the iteration.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Cursor returns the start cursor of the page which the current result belongs to.
+// The iteration can be resumed from the page by the cursor.
+func (it *Iterator[T]) Cursor() string {
+	return it.cursor
+}
+
+// Close releases the response which is being read.
+// It is safe to call Close after the iteration is finished.
+func (it *Iterator[T]) Close() error {
+	it.done = true
+	if it.res != nil {
+		err := it.res.Body.Close()
+		it.res, it.dec = nil, nil
+		return err
+	}
+	return nil
+}
+
+func (it *Iterator[T]) fail(err error) {
+	it.err = fmt.Errorf("failed parse a response: %w", err)
+	it.Close()
+}
+
+// collect returns all results of the iterator.
+// If the iteration is interrupted, collect returns the results of the pages which are read completely with *PaginationError.
+func collect[T any](it *Iterator[T]) ([]T, error) {
+	defer it.Close()
+
+	results := make([]T, 0)
+	var pending []T
+	cursor := it.Cursor()
+	for it.Next() {
+		if it.Cursor() != cursor {
+			results = append(results, pending...)
+			pending = pending[:0]
+			cursor = it.Cursor()
+		}
+		pending = append(pending, it.Value())
+	}
+	if it.Cursor() != cursor {
+		// The previous page was read completely before the error.
+		results = append(results, pending...)
+		pending = nil
+	}
+	if err := it.Err(); err != nil {
+		return results, &PaginationError{NextCursor: it.Cursor(), Err: err}
+	}
+
+	return append(results, pending...), nil
+}
+
+// IteratePages returns the iterator of the pages which belongs to the database.
+// ref: https://developers.notion.com/reference/post-database-query
+func (c *Client) IteratePages(ctx context.Context, databaseID string, filter *Filter, sorts []*Sort) *Iterator[*Page] {
+	return newIterator(ctx, "", c.queryPagesFetcher(fmt.Sprintf("/databases/%s/query", databaseID), filter, sorts), decodePage)
+}
+
+// IterateDataSource returns the iterator of the pages which belongs to the data source.
+// ref: https://developers.notion.com/reference/query-a-data-source
+func (c *Client) IterateDataSource(ctx context.Context, dataSourceID string, filter *Filter, sorts []*Sort) *Iterator[*Page] {
+	it := newIterator(ctx, "", c.queryPagesFetcher(fmt.Sprintf("/data_sources/%s/query", dataSourceID), filter, sorts), decodePage)
+	if !c.supportsDataSource() {
+		it.err = c.unsupportedVersionError(Version20250903)
+	}
+	return it
+}
+
+// IterateSearch returns the iterator of the search results.
+// The value of the iterator is *Page, *Database or *DataSource.
+// ref: https://developers.notion.com/reference/post-search
+func (c *Client) IterateSearch(ctx context.Context, query string, sort *Sort) *Iterator[Object] {
+	return newIterator(ctx, "", c.searchFetcher(query, sort), decodeSearchResult)
+}
+
+func (c *Client) queryPagesFetcher(apiPath string, filter *Filter, sorts []*Sort) func(context.Context, string) (*http.Response, error) {
+	return func(ctx context.Context, startCursor string) (*http.Response, error) {
+		data := &struct {
+			Filter      *Filter `json:"filter,omitempty"`
+			Sorts       []*Sort `json:"sorts,omitempty"`
+			PageSize    int     `json:"page_size"`
+			StartCursor string  `json:"start_cursor,omitempty"`
+		}{
+			Filter: filter, Sorts: sorts, StartCursor: startCursor,
+		}
+		return c.postList(ctx, apiPath, data)
+	}
+}
+
+func (c *Client) searchFetcher(query string, sort *Sort) func(context.Context, string) (*http.Response, error) {
+	return func(ctx context.Context, startCursor string) (*http.Response, error) {
+		body := struct {
+			Query       string `json:"query"`
+			Sort        *Sort  `json:"sort,omitempty"`
+			StartCursor string `json:"start_cursor,omitempty"`
+			PageSize    int    `json:"page_size"`
+		}{
+			Query:       query,
+			Sort:        sort,
+			StartCursor: startCursor,
+			PageSize:    100,
+		}
+		return c.postList(ctx, "/search", body)
+	}
+}
+
+// postList sends the request to the list endpoint.
+// The caller has to close the body of the response.
+func (c *Client) postList(ctx context.Context, apiPath string, body interface{}) (*http.Response, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, apiPath, nil, buf)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	default:
+		defer res.Body.Close()
+		return nil, c.decodeError(res)
+	}
+
+	return res, nil
+}
+
+func decodePage(d *listDecoder) (*Page, error) {
+	page := &Page{}
+	if err := d.Decode(page); err != nil {
+		return nil, err
+	}
+	if err := page.decode(); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// decodeSearchResult decodes the result of the search.
+// The type of the result is determined by peeking "object" field in the buffered data,
+// so that the result is decoded from the stream directly into the concrete type.
+func decodeSearchResult(d *listDecoder) (Object, error) {
+	if typ, ok := d.peekObjectType(); ok {
+		return decodeObject(typ, d.Decode)
+	}
+
+	// "object" field is not at the head of the result. Fall back to buffering the result.
+	var raw json.RawMessage
+	if err := d.Decode(&raw); err != nil {
+		return nil, err
+	}
+	typ, err := peekObjectType(raw)
+	if err != nil {
+		return nil, err
+	}
+	return decodeObject(typ, func(v interface{}) error { return json.Unmarshal(raw, v) })
+}
+
+func decodeObject(typ ObjectType, decode func(v interface{}) error) (Object, error) {
+	switch typ {
+	case ObjectTypeDatabase:
+		db := &Database{}
+		if err := decode(db); err != nil {
+			return nil, err
+		}
+		if err := db.decode(); err != nil {
+			return nil, err
+		}
+		return db, nil
+	case ObjectTypePage:
+		page := &Page{}
+		if err := decode(page); err != nil {
+			return nil, err
+		}
+		if err := page.decode(); err != nil {
+			return nil, err
+		}
+		return page, nil
+	case ObjectTypeDataSource:
+		ds := &DataSource{}
+		if err := decode(ds); err != nil {
+			return nil, err
+		}
+		if err := ds.decode(); err != nil {
+			return nil, err
+		}
+		return ds, nil
+	default:
+		return nil, fmt.Errorf("notion: unknown object type: %s", typ)
+	}
+}
+
+// peekObjectType returns the value of "object" field without decoding the entire object.
+// Notion API puts "object" field at first in most cases.
+func peekObjectType(raw json.RawMessage) (ObjectType, error) {
+	if typ, ok := headObjectType(raw); ok {
+		return typ, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if err := expectDelim(dec, '{'); err != nil {
+		return "", err
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		if key, _ := t.(string); key == "object" {
+			var typ ObjectType
+			if err := dec.Decode(&typ); err != nil {
+				return "", err
+			}
+			return typ, nil
+		}
+		if err := skipValue(dec); err != nil {
+			return "", err
+		}
+	}
+
+	return "", errors.New("notion: object field is not found")
+}
+
+// headObjectType returns the value of "object" field if b starts with it (e.g. {"object":"page",...).
+func headObjectType(b []byte) (ObjectType, bool) {
+	b = bytes.TrimLeft(b, " \t\r\n")
+	if len(b) == 0 || b[0] != '{' {
+		return "", false
+	}
+	b = bytes.TrimLeft(b[1:], " \t\r\n")
+	if !bytes.HasPrefix(b, []byte(`"object"`)) {
+		return "", false
+	}
+	b = bytes.TrimLeft(b[len(`"object"`):], " \t\r\n")
+	if len(b) == 0 || b[0] != ':' {
+		return "", false
+	}
+	b = bytes.TrimLeft(b[1:], " \t\r\n")
+	if len(b) == 0 || b[0] != '"' {
+		return "", false
+	}
+	i := bytes.IndexByte(b[1:], '"')
+	if i < 0 || bytes.IndexByte(b[1:i+1], '\\') >= 0 {
+		return "", false
+	}
+	return knownObjectType(b[1 : i+1]), true
+}
+
+// knownObjectType converts b to ObjectType without the allocation for known types.
+func knownObjectType(b []byte) ObjectType {
+	switch string(b) {
+	case string(ObjectTypePage):
+		return ObjectTypePage
+	case string(ObjectTypeDatabase):
+		return ObjectTypeDatabase
+	case string(ObjectTypeDataSource):
+		return ObjectTypeDataSource
+	}
+	return ObjectType(b)
+}
+
+// listDecoder decodes the list object by streaming.
+type listDecoder struct {
+	dec       *json.Decoder
+	meta      ListMeta
+	inResults bool
+	closed    bool
+	// head is the buffer to peek the head of the next element.
+	head [64]byte
+}
+
+func newListDecoder(r io.Reader) *listDecoder {
+	return &listDecoder{dec: json.NewDecoder(r)}
+}
+
+// start reads the list object until the beginning of results.
+func (d *listDecoder) start() error {
+	if err := expectDelim(d.dec, '{'); err != nil {
+		return err
+	}
+	return d.readFields(true)
+}
+
+// More reports whether there is another element in results.
+func (d *listDecoder) More() bool {
+	return d.inResults && d.dec.More()
+}
+
+// Decode decodes the next element of results.
+func (d *listDecoder) Decode(v interface{}) error {
+	return d.dec.Decode(v)
+}
+
+// peekObjectType returns the value of "object" field of the next element without consuming it.
+// ok is false if the buffered data doesn't start with "object" field.
+func (d *listDecoder) peekObjectType() (typ ObjectType, ok bool) {
+	n, _ := d.dec.Buffered().Read(d.head[:])
+	// The separator of the previous element may remain in the buffer.
+	return headObjectType(bytes.TrimLeft(d.head[:n], " \t\r\n,"))
+}
+
+// finish reads the rest of the list object and returns the metadata of the list.
+func (d *listDecoder) finish() (*ListMeta, error) {
+	if d.inResults {
+		for d.dec.More() {
+			if err := skipValue(d.dec); err != nil {
+				return nil, err
+			}
+		}
+		if err := expectDelim(d.dec, ']'); err != nil {
+			return nil, err
+		}
+		d.inResults = false
+	}
+	if !d.closed {
+		if err := d.readFields(false); err != nil {
+			return nil, err
+		}
+	}
+
+	return &d.meta, nil
+}
+
+// readFields reads the fields of the list object.
+// If untilResults is true, readFields stops at the beginning of results.
+func (d *listDecoder) readFields(untilResults bool) error {
+	for d.dec.More() {
+		t, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := t.(string)
+		if !ok {
+			return fmt.Errorf("unexpected token: %v", t)
+		}
+
+		switch key {
+		case "results":
+			if !untilResults {
+				return errors.New("results appear twice")
+			}
+			t, err := d.dec.Token()
+			if err != nil {
+				return err
+			}
+			if t == nil {
+				// results is null
+				continue
+			}
+			if delim, ok := t.(json.Delim); !ok || delim != '[' {
+				return fmt.Errorf("unexpected token: %v", t)
+			}
+			d.inResults = true
+			return nil
+		case "object":
+			if err := d.dec.Decode(&d.meta.Object); err != nil {
+				return err
+			}
+		case "has_more":
+			if err := d.dec.Decode(&d.meta.HasMore); err != nil {
+				return err
+			}
+		case "next_cursor":
+			if err := d.dec.Decode(&d.meta.NextCursor); err != nil {
+				return err
+			}
+		default:
+			if err := skipValue(d.dec); err != nil {
+				return err
+			}
+		}
+	}
+	if err := expectDelim(d.dec, '}'); err != nil {
+		return err
+	}
+	d.closed = true
+
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := t.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("unexpected token: %v", t)
+	}
+	return nil
+}
+
+// skipValue skips the next value without decoding.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if delim, ok := t.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIteratePages(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/post-database-query.json")
+	require.NoError(t, err)
+	firstPage := withNextCursor(t, res, "cursor-1")
+
+	var cursors []string
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/databases/[a-z0-9-]{36}/query`),
+		func(req *http.Request) (*http.Response, error) {
+			body := struct {
+				StartCursor string `json:"start_cursor"`
+			}{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			cursors = append(cursors, body.StartCursor)
+			if body.StartCursor == "" {
+				return httpmock.NewStringResponse(http.StatusOK, firstPage), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, string(res)), nil
+		},
+	)
+
+	client, err := New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+
+	it := client.IteratePages(context.Background(), "a4f18e20-365d-4fe1-91e8-080381f877d5", nil, nil)
+	defer it.Close()
+	var pages []*Page
+	var pageCursors []string
+	for it.Next() {
+		pages = append(pages, it.Value())
+		pageCursors = append(pageCursors, it.Cursor())
+	}
+	require.NoError(t, it.Err())
+
+	require.Len(t, pages, 2)
+	assert.Equal(t, "16493215-50a8-41b8-8b43-0a0c014a7910", pages[0].ID)
+	assert.Equal(t, "Foo", pages[1].Properties["Name"].String())
+	assert.Equal(t, []string{"", "cursor-1"}, pageCursors)
+	assert.Equal(t, []string{"", "cursor-1"}, cursors)
+}
+
+func TestIterateSearch(t *testing.T) {
+	t.Parallel()
+
+	rt := mockTransport(t, http.MethodPost, `/v1/search$`, http.StatusOK, "./testdata/post-search.json")
+	client, err := New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+
+	it := client.IterateSearch(context.Background(), "q", nil)
+	defer it.Close()
+	var results []Object
+	for it.Next() {
+		results = append(results, it.Value())
+	}
+	require.NoError(t, it.Err())
+
+	require.Len(t, results, 2)
+	assert.IsType(t, &Page{}, results[0])
+	assert.IsType(t, &Database{}, results[1])
+}
+
+func TestListDecoder(t *testing.T) {
+	t.Run("ResultsAfterMeta", func(t *testing.T) {
+		d := newListDecoder(bytes.NewReader([]byte(`{"object":"list","next_cursor":"foo","has_more":true,"type":"page","page":{},"results":[{"id":"a"},{"id":"b"}]}`)))
+		require.NoError(t, d.start())
+		var ids []string
+		for d.More() {
+			m := &Meta{}
+			require.NoError(t, d.Decode(m))
+			ids = append(ids, m.ID)
+		}
+		meta, err := d.finish()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, ids)
+		assert.True(t, meta.HasMore)
+		assert.Equal(t, "foo", meta.NextCursor)
+	})
+
+	t.Run("StopInTheMiddle", func(t *testing.T) {
+		d := newListDecoder(bytes.NewReader([]byte(`{"results":[{"id":"a"},{"id":"b"}],"next_cursor":null,"has_more":false}`)))
+		require.NoError(t, d.start())
+		require.True(t, d.More())
+		require.NoError(t, d.Decode(&Meta{}))
+		meta, err := d.finish()
+		require.NoError(t, err)
+		assert.False(t, meta.HasMore)
+		assert.Empty(t, meta.NextCursor)
+	})
+
+	t.Run("NullResults", func(t *testing.T) {
+		d := newListDecoder(bytes.NewReader([]byte(`{"results":null,"has_more":false}`)))
+		require.NoError(t, d.start())
+		assert.False(t, d.More())
+		_, err := d.finish()
+		require.NoError(t, err)
+	})
+}
+
+func TestPeekObjectType(t *testing.T) {
+	typ, err := peekObjectType(json.RawMessage(`{"object":"page","properties":{"object":"database"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, ObjectTypePage, typ)
+
+	typ, err = peekObjectType(json.RawMessage(`{"id":"a","properties":{"object":"page"},"title":[{"type":"text"}],"object":"database"}`))
+	require.NoError(t, err)
+	assert.Equal(t, ObjectTypeDatabase, typ)
+
+	_, err = peekObjectType(json.RawMessage(`{"id":"a"}`))
+	assert.Error(t, err)
+}
+
+func TestDecodeSearchResult(t *testing.T) {
+	t.Parallel()
+
+	// The first result starts with "object" field and is decoded from the stream directly.
+	// The second result doesn't, so it is decoded through the buffer.
+	d := newListDecoder(strings.NewReader(`{"object":"list","results":[` +
+		`{"object":"page","id":"a","properties":{}},` +
+		` {"id":"b","title":[],"properties":{},"object":"database"}` +
+		`],"next_cursor":null,"has_more":false}`))
+	require.NoError(t, d.start())
+
+	var results []Object
+	for d.More() {
+		v, err := decodeSearchResult(d)
+		require.NoError(t, err)
+		results = append(results, v)
+	}
+	_, err := d.finish()
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	if assert.IsType(t, &Page{}, results[0]) {
+		assert.Equal(t, "a", results[0].(*Page).ID)
+	}
+	if assert.IsType(t, &Database{}, results[1]) {
+		assert.Equal(t, "b", results[1].(*Database).ID)
+	}
+}
+
+// largeList returns the list response which has n results.
+func largeList(b *testing.B, file string, n int) []byte {
+	res, err := os.ReadFile(file)
+	require.NoError(b, err)
+	obj := struct {
+		Object     string            `json:"object"`
+		Results    []json.RawMessage `json:"results"`
+		NextCursor *string           `json:"next_cursor"`
+		HasMore    bool              `json:"has_more"`
+	}{}
+	require.NoError(b, json.Unmarshal(res, &obj))
+	results := obj.Results
+	for len(obj.Results) < n {
+		obj.Results = append(obj.Results, results...)
+	}
+	buf, err := json.Marshal(obj)
+	require.NoError(b, err)
+
+	return buf
+}
+
+// BenchmarkDecodePageList compares decoding the whole list with streaming.
+// The number of allocations is dominated by decoding each page, so it is almost the same.
+// Streaming reduces the bytes because the list of all pages is not kept.
+func BenchmarkDecodePageList(b *testing.B) {
+	buf := largeList(b, "./testdata/post-database-query.json", 100)
+
+	b.Run("Full", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			obj := &PageList{}
+			if err := json.NewDecoder(bytes.NewReader(buf)).Decode(obj); err != nil {
+				b.Fatal(err)
+			}
+			for _, v := range obj.Results {
+				if err := v.decode(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("Streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			d := newListDecoder(bytes.NewReader(buf))
+			if err := d.start(); err != nil {
+				b.Fatal(err)
+			}
+			for d.More() {
+				if _, err := decodePage(d); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if _, err := d.finish(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkDecodeSearchResult(b *testing.B) {
+	buf := largeList(b, "./testdata/post-search.json", 100)
+
+	// Legacy is the implementation which decodes each result twice.
+	b.Run("Legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			obj := &SearchResult{}
+			if err := json.NewDecoder(bytes.NewReader(buf)).Decode(obj); err != nil {
+				b.Fatal(err)
+			}
+			meta := &Meta{}
+			for _, v := range obj.Results {
+				if err := json.Unmarshal(*v, meta); err != nil {
+					b.Fatal(err)
+				}
+				switch meta.Object {
+				case ObjectTypeDatabase:
+					if err := json.Unmarshal(*v, &Database{}); err != nil {
+						b.Fatal(err)
+					}
+				case ObjectTypePage:
+					if err := json.Unmarshal(*v, &Page{}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		}
+	})
+
+	b.Run("Streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			d := newListDecoder(bytes.NewReader(buf))
+			if err := d.start(); err != nil {
+				b.Fatal(err)
+			}
+			for d.More() {
+				if _, err := decodeSearchResult(d); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if _, err := d.finish(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
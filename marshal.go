@@ -0,0 +1,500 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// tagName is the name of the struct tag for UnmarshalPage and MarshalProperties.
+// The tag is `notion:"Property Name,type"`. The type is optional (e.g. title, number, select).
+// The name may have the comma. Only the property type after the last comma is the type.
+const tagName = "notion"
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	dateType         = reflect.TypeOf(Date{})
+	datePropertyType = reflect.TypeOf(DateProperty{})
+	userType         = reflect.TypeOf(User{})
+	optionType       = reflect.TypeOf(Option{})
+	richTextType     = reflect.TypeOf(RichTextObject{})
+)
+
+type structField struct {
+	Index []int
+	Name  string
+	// Property is the name of the property.
+	Property string
+	// Type is the type of the property which is specified by the tag. Type is empty if it is not specified.
+	Type PropertyType
+}
+
+// structFields returns the fields which have the tag.
+func structFields(t reflect.Type) []*structField {
+	var fields []*structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup(tagName)
+		if !ok || tag == "-" || !f.IsExported() {
+			continue
+		}
+		name, typ := parseTag(tag)
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, &structField{Index: f.Index, Name: f.Name, Property: name, Type: PropertyType(typ)})
+	}
+	return fields
+}
+
+// parseTag returns the name and the type of the property in the tag.
+// The name of the property may have the comma (e.g. "Cost, USD"),
+// so only the known property type after the last comma is treated as the type.
+func parseTag(tag string) (string, PropertyType) {
+	i := strings.LastIndexByte(tag, ',')
+	if i < 0 {
+		return tag, ""
+	}
+	typ := PropertyType(tag[i+1:])
+	if typ != "" && !isPropertyType(typ) {
+		return tag, ""
+	}
+	return tag[:i], typ
+}
+
+func isPropertyType(t PropertyType) bool {
+	switch t {
+	case PropertyTypeTitle, PropertyTypeText, PropertyTypeRichText, PropertyTypeNumber, PropertyTypeSelect,
+		PropertyTypeMultiSelect, PropertyTypeStatus, PropertyTypeDate, PropertyTypePeople, PropertyTypeFiles,
+		PropertyTypeCheckbox, PropertyTypeURL, PropertyTypeEmail, PropertyTypePhoneNumber, PropertyTypeFormula,
+		PropertyTypeRelation, PropertyTypeRollup, PropertyTypeCreatedTime, PropertyTypeCreatedBy,
+		PropertyTypeLastEditedTime, PropertyTypeLastEditedBy, PropertyTypeUniqueID:
+		return true
+	}
+	return false
+}
+
+func typeMismatchError(f *structField, t reflect.Type, propType PropertyType) error {
+	return fmt.Errorf("%w: %s (%s) can't be %s property %q", ErrPropertyTypeMismatch, f.Name, t, propType, f.Property)
+}
+
+// UnmarshalPage stores the properties of the page in the struct which v points to.
+// The field of the struct is mapped to the property by the tag `notion:"Property Name,type"`.
+// If the type is specified, the type of the property has to be the same.
+//
+// The supported types of the field for each property type are:
+//
+//	title, rich_text:              string, []*RichTextObject
+//	number:                        int, uint, float and the pointer of them
+//	select, status:                string, *Option
+//	multi_select:                  []string, []*Option
+//	date:                          time.Time, *time.Time, *Date, *DateProperty
+//	people:                        []string (the IDs of the users), []*User
+//	relation:                      []string (the IDs of the pages)
+//	checkbox:                      bool
+//	url, email, phone_number:      string
+//
+// If the number can't be represented by the type of the field (e.g. 2.5 for int), UnmarshalPage returns ErrPropertyTypeMismatch.
+func UnmarshalPage(page *Page, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("notion: v must be the non-nil pointer of the struct")
+	}
+	rv = rv.Elem()
+
+	for _, f := range structFields(rv.Type()) {
+		prop, ok := page.Properties[f.Property]
+		if !ok || prop == nil {
+			return fmt.Errorf("%w: %s", ErrPropertyNotFound, f.Property)
+		}
+		if f.Type != "" && f.Type != prop.Type {
+			return fmt.Errorf("%w: %s is %s, not %s", ErrPropertyTypeMismatch, f.Property, prop.Type, f.Type)
+		}
+		if err := unmarshalProperty(prop, rv.FieldByIndex(f.Index), f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unmarshalProperty(prop *PropertyData, fv reflect.Value, f *structField) error {
+	ft := fv.Type()
+	mismatch := func() error { return typeMismatchError(f, ft, prop.Type) }
+
+	switch prop.Type {
+	case PropertyTypeTitle, PropertyTypeRichText:
+		objs := prop.Title
+		if prop.Type == PropertyTypeRichText {
+			objs = prop.RichText
+		}
+		switch {
+		case ft.Kind() == reflect.String:
+			fv.SetString(plainText(objs))
+		case ft.Kind() == reflect.Slice && ft.Elem() == reflect.PtrTo(richTextType):
+			fv.Set(reflect.ValueOf(objs))
+		default:
+			return mismatch()
+		}
+	case PropertyTypeNumber:
+		if ft.Kind() == reflect.Ptr {
+			if prop.Number == nil {
+				fv.Set(reflect.Zero(ft))
+				return nil
+			}
+			p := reflect.New(ft.Elem())
+			if !setNumber(p.Elem(), *prop.Number) {
+				return mismatch()
+			}
+			fv.Set(p)
+			return nil
+		}
+		var n float64
+		if prop.Number != nil {
+			n = *prop.Number
+		}
+		if !setNumber(fv, n) {
+			return mismatch()
+		}
+	case PropertyTypeSelect, PropertyTypeStatus:
+		o := prop.Select
+		if prop.Type == PropertyTypeStatus {
+			o = prop.Status
+		}
+		switch {
+		case ft.Kind() == reflect.String:
+			if o == nil {
+				fv.SetString("")
+			} else {
+				fv.SetString(o.Name)
+			}
+		case ft == reflect.PtrTo(optionType):
+			fv.Set(reflect.ValueOf(o))
+		default:
+			return mismatch()
+		}
+	case PropertyTypeMultiSelect:
+		switch {
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.String:
+			names := reflect.MakeSlice(ft, 0, len(prop.MultiSelect))
+			for _, o := range prop.MultiSelect {
+				names = reflect.Append(names, reflect.ValueOf(o.Name).Convert(ft.Elem()))
+			}
+			fv.Set(names)
+		case ft.Kind() == reflect.Slice && ft.Elem() == reflect.PtrTo(optionType):
+			fv.Set(reflect.ValueOf(prop.MultiSelect))
+		default:
+			return mismatch()
+		}
+	case PropertyTypeDate:
+		d := prop.Date
+		switch ft {
+		case timeType:
+			if d == nil || d.Start == nil {
+				fv.Set(reflect.Zero(ft))
+			} else {
+				fv.Set(reflect.ValueOf(d.Start.Time))
+			}
+		case reflect.PtrTo(timeType):
+			if d == nil || d.Start == nil {
+				fv.Set(reflect.Zero(ft))
+			} else {
+				t := d.Start.Time
+				fv.Set(reflect.ValueOf(&t))
+			}
+		case reflect.PtrTo(dateType):
+			if d == nil {
+				fv.Set(reflect.Zero(ft))
+			} else {
+				fv.Set(reflect.ValueOf(d.Start))
+			}
+		case reflect.PtrTo(datePropertyType):
+			fv.Set(reflect.ValueOf(d))
+		default:
+			return mismatch()
+		}
+	case PropertyTypePeople:
+		switch {
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.String:
+			ids := reflect.MakeSlice(ft, 0, len(prop.People))
+			for _, u := range prop.People {
+				ids = reflect.Append(ids, reflect.ValueOf(u.ID).Convert(ft.Elem()))
+			}
+			fv.Set(ids)
+		case ft.Kind() == reflect.Slice && ft.Elem() == reflect.PtrTo(userType):
+			fv.Set(reflect.ValueOf(prop.People))
+		default:
+			return mismatch()
+		}
+	case PropertyTypeRelation:
+		if ft.Kind() != reflect.Slice || ft.Elem().Kind() != reflect.String {
+			return mismatch()
+		}
+		ids := reflect.MakeSlice(ft, 0, len(prop.Relation))
+		for _, r := range prop.Relation {
+			ids = reflect.Append(ids, reflect.ValueOf(r.ID).Convert(ft.Elem()))
+		}
+		fv.Set(ids)
+	case PropertyTypeCheckbox:
+		if ft.Kind() != reflect.Bool {
+			return mismatch()
+		}
+		fv.SetBool(prop.Checkbox)
+	case PropertyTypeURL, PropertyTypeEmail, PropertyTypePhoneNumber:
+		if ft.Kind() != reflect.String {
+			return mismatch()
+		}
+		switch prop.Type {
+		case PropertyTypeURL:
+			fv.SetString(prop.URL)
+		case PropertyTypeEmail:
+			fv.SetString(prop.Email)
+		case PropertyTypePhoneNumber:
+			fv.SetString(prop.PhoneNumber)
+		}
+	default:
+		return fmt.Errorf("notion: %s property %q is not supported", prop.Type, f.Property)
+	}
+
+	return nil
+}
+
+// setNumber stores n in v. setNumber returns false if v is not the number or n can't be represented by the type of v
+// (e.g. the fraction for int, the negative number for uint and the overflow).
+func setNumber(v reflect.Value, n float64) bool {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if v.OverflowFloat(n) {
+			return false
+		}
+		v.SetFloat(n)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// -2^63 is the minimum of int64 and 2^63 is out of range.
+		if n != math.Trunc(n) || n < math.MinInt64 || n >= -math.MinInt64 || v.OverflowInt(int64(n)) {
+			return false
+		}
+		v.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n != math.Trunc(n) || n < 0 || n >= 1<<64 || v.OverflowUint(uint64(n)) {
+			return false
+		}
+		v.SetUint(uint64(n))
+	default:
+		return false
+	}
+	return true
+}
+
+func getNumber(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	}
+	return 0, false
+}
+
+// MarshalProperties returns the properties of the struct which v is or points to.
+// The type of the property is decided by schema, which is the properties of the database (or the data source since 2025-09-03).
+// If schema is nil, the type has to be specified by the tag.
+// The supported types of the field are the same as UnmarshalPage.
+//
+// The empty values (e.g. the empty string, the zero time and nil) are not included in the properties
+// because the API can't distinguish them from the unset values.
+// Therefore the properties can't clear the value. On updating the page, the property which has the empty value is kept as it is.
+func MarshalProperties(v interface{}, schema map[string]*PropertyMetadata) (map[string]*PropertyData, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, errors.New("notion: v must not be nil")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("notion: v must be the struct or the pointer of the struct")
+	}
+
+	properties := make(map[string]*PropertyData)
+	for _, f := range structFields(rv.Type()) {
+		t := f.Type
+		if schema != nil {
+			meta, ok := schema[f.Property]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrPropertyNotFound, f.Property)
+			}
+			if t != "" && t != meta.Type {
+				return nil, fmt.Errorf("%w: %s is %s, not %s", ErrPropertyTypeMismatch, f.Property, meta.Type, t)
+			}
+			t = meta.Type
+		}
+		if t == "" {
+			return nil, fmt.Errorf("notion: the type of %q is not specified", f.Property)
+		}
+
+		prop, err := marshalProperty(rv.FieldByIndex(f.Index), t, f)
+		if err != nil {
+			return nil, err
+		}
+		if prop != nil {
+			properties[f.Property] = prop
+		}
+	}
+
+	return properties, nil
+}
+
+// marshalProperty returns the property of the value. If the value is empty, marshalProperty returns nil.
+func marshalProperty(fv reflect.Value, t PropertyType, f *structField) (*PropertyData, error) {
+	ft := fv.Type()
+	mismatch := func() error { return typeMismatchError(f, ft, t) }
+	if fv.Kind() == reflect.Ptr && fv.IsNil() {
+		switch ft.Elem().Kind() {
+		case reflect.Struct, reflect.Float32, reflect.Float64,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return nil, nil
+		}
+		return nil, mismatch()
+	}
+
+	prop := &PropertyData{Type: t}
+	switch t {
+	case PropertyTypeTitle, PropertyTypeRichText:
+		var objs []*RichTextObject
+		switch {
+		case ft.Kind() == reflect.String:
+			if fv.String() == "" {
+				return nil, nil
+			}
+			objs = RichText().Text(fv.String()).Build()
+		case ft.Kind() == reflect.Slice && ft.Elem() == reflect.PtrTo(richTextType):
+			objs = fv.Interface().([]*RichTextObject)
+		default:
+			return nil, mismatch()
+		}
+		if len(objs) == 0 {
+			return nil, nil
+		}
+		if t == PropertyTypeTitle {
+			prop.Title = objs
+		} else {
+			prop.RichText = objs
+		}
+	case PropertyTypeNumber:
+		nv := fv
+		if nv.Kind() == reflect.Ptr {
+			nv = nv.Elem()
+		}
+		n, ok := getNumber(nv)
+		if !ok {
+			return nil, mismatch()
+		}
+		prop.Number = &n
+	case PropertyTypeSelect, PropertyTypeStatus:
+		var o *Option
+		switch {
+		case ft.Kind() == reflect.String:
+			if fv.String() == "" {
+				return nil, nil
+			}
+			o = &Option{Name: fv.String()}
+		case ft == reflect.PtrTo(optionType):
+			o = fv.Interface().(*Option)
+		default:
+			return nil, mismatch()
+		}
+		if t == PropertyTypeSelect {
+			prop.Select = o
+		} else {
+			prop.Status = o
+		}
+	case PropertyTypeMultiSelect:
+		switch {
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.String:
+			for i := 0; i < fv.Len(); i++ {
+				prop.MultiSelect = append(prop.MultiSelect, &Option{Name: fv.Index(i).String()})
+			}
+		case ft.Kind() == reflect.Slice && ft.Elem() == reflect.PtrTo(optionType):
+			prop.MultiSelect = fv.Interface().([]*Option)
+		default:
+			return nil, mismatch()
+		}
+		if len(prop.MultiSelect) == 0 {
+			return nil, nil
+		}
+	case PropertyTypeDate:
+		switch ft {
+		case timeType, reflect.PtrTo(timeType):
+			tv := fv
+			if tv.Kind() == reflect.Ptr {
+				tv = tv.Elem()
+			}
+			tm := tv.Interface().(time.Time)
+			if tm.IsZero() {
+				return nil, nil
+			}
+			prop.Date = &DateProperty{Start: NewDateTime(tm)}
+		case reflect.PtrTo(dateType):
+			prop.Date = &DateProperty{Start: fv.Interface().(*Date)}
+		case reflect.PtrTo(datePropertyType):
+			prop.Date = fv.Interface().(*DateProperty)
+		default:
+			return nil, mismatch()
+		}
+	case PropertyTypePeople:
+		switch {
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.String:
+			for i := 0; i < fv.Len(); i++ {
+				prop.People = append(prop.People, &User{Meta: &Meta{Object: ObjectTypeUser, ID: fv.Index(i).String()}})
+			}
+		case ft.Kind() == reflect.Slice && ft.Elem() == reflect.PtrTo(userType):
+			prop.People = fv.Interface().([]*User)
+		default:
+			return nil, mismatch()
+		}
+		if len(prop.People) == 0 {
+			return nil, nil
+		}
+	case PropertyTypeRelation:
+		if ft.Kind() != reflect.Slice || ft.Elem().Kind() != reflect.String {
+			return nil, mismatch()
+		}
+		for i := 0; i < fv.Len(); i++ {
+			prop.Relation = append(prop.Relation, &Meta{ID: fv.Index(i).String()})
+		}
+		if len(prop.Relation) == 0 {
+			return nil, nil
+		}
+	case PropertyTypeCheckbox:
+		if ft.Kind() != reflect.Bool {
+			return nil, mismatch()
+		}
+		prop.Checkbox = fv.Bool()
+	case PropertyTypeURL, PropertyTypeEmail, PropertyTypePhoneNumber:
+		if ft.Kind() != reflect.String {
+			return nil, mismatch()
+		}
+		s := fv.String()
+		if s == "" {
+			return nil, nil
+		}
+		switch t {
+		case PropertyTypeURL:
+			prop.URL = s
+		case PropertyTypeEmail:
+			prop.Email = s
+		case PropertyTypePhoneNumber:
+			prop.PhoneNumber = s
+		}
+	default:
+		return nil, fmt.Errorf("notion: %s property %q is not supported", t, f.Property)
+	}
+
+	return prop, nil
+}
@@ -0,0 +1,195 @@
+package notion
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type task struct {
+	Name     string    `notion:"Name,title"`
+	Note     string    `notion:"Note"`
+	Estimate float64   `notion:"Estimate"`
+	Points   *int      `notion:"Points"`
+	Priority string    `notion:"Priority,select"`
+	Tags     []string  `notion:"Tags"`
+	Due      time.Time `notion:"Due"`
+	Owners   []string  `notion:"Owner"`
+	Parent   []string  `notion:"Parent"`
+	Done     bool      `notion:"Done"`
+	Link     string    `notion:"Link"`
+	Status   string    `notion:"Status,status"`
+	Ignored  string
+}
+
+func TestUnmarshalPage(t *testing.T) {
+	t.Parallel()
+
+	page := &Page{}
+	err := json.Unmarshal([]byte(`{
+		"object": "page",
+		"id": "56f2049d-feb1-4a3f-b227-2fa76ca74d0e",
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "Write docs"}, "plain_text": "Write docs"}]},
+			"Note": {"id": "a", "type": "rich_text", "rich_text": []},
+			"Estimate": {"id": "b", "type": "number", "number": 2.5},
+			"Points": {"id": "c", "type": "number", "number": null},
+			"Priority": {"id": "d", "type": "select", "select": {"id": "o1", "name": "High", "color": "red"}},
+			"Tags": {"id": "e", "type": "multi_select", "multi_select": [{"name": "docs"}, {"name": "api"}]},
+			"Due": {"id": "f", "type": "date", "date": {"start": "2024-03-01T10:00:00.000+09:00"}},
+			"Owner": {"id": "g", "type": "people", "people": [{"object": "user", "id": "u1"}]},
+			"Parent": {"id": "h", "type": "relation", "relation": [{"id": "c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1"}]},
+			"Done": {"id": "i", "type": "checkbox", "checkbox": true},
+			"Link": {"id": "j", "type": "url", "url": "https://example.com"},
+			"Status": {"id": "k", "type": "status", "status": {"name": "In progress"}}
+		}
+	}`), page)
+	require.NoError(t, err)
+
+	v := &task{Ignored: "keep"}
+	require.NoError(t, UnmarshalPage(page, v))
+	assert.Equal(t, "Write docs", v.Name)
+	assert.Equal(t, "", v.Note)
+	assert.Equal(t, 2.5, v.Estimate)
+	assert.Nil(t, v.Points)
+	assert.Equal(t, "High", v.Priority)
+	assert.Equal(t, []string{"docs", "api"}, v.Tags)
+	assert.True(t, time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC).Equal(v.Due))
+	assert.Equal(t, []string{"u1"}, v.Owners)
+	assert.Equal(t, []string{"c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1"}, v.Parent)
+	assert.True(t, v.Done)
+	assert.Equal(t, "https://example.com", v.Link)
+	assert.Equal(t, "In progress", v.Status)
+	assert.Equal(t, "keep", v.Ignored)
+
+	err = UnmarshalPage(page, &struct {
+		Priority int `notion:"Priority"`
+	}{})
+	assert.ErrorIs(t, err, ErrPropertyTypeMismatch)
+	err = UnmarshalPage(page, &struct {
+		Priority string `notion:"Priority,status"`
+	}{})
+	assert.ErrorIs(t, err, ErrPropertyTypeMismatch)
+	err = UnmarshalPage(page, &struct {
+		Unknown string `notion:"Unknown"`
+	}{})
+	assert.ErrorIs(t, err, ErrPropertyNotFound)
+	assert.Error(t, UnmarshalPage(page, task{}))
+}
+
+func TestUnmarshalPage_Number(t *testing.T) {
+	t.Parallel()
+
+	newPage := func(n float64) *Page {
+		return &Page{Properties: map[string]*PropertyData{"Count": {Type: PropertyTypeNumber, Number: &n}}}
+	}
+
+	v := &struct {
+		Count int8 `notion:"Count"`
+	}{}
+	require.NoError(t, UnmarshalPage(newPage(-3), v))
+	assert.Equal(t, int8(-3), v.Count)
+	assert.ErrorIs(t, UnmarshalPage(newPage(2.5), v), ErrPropertyTypeMismatch)
+	assert.ErrorIs(t, UnmarshalPage(newPage(128), v), ErrPropertyTypeMismatch)
+
+	u := &struct {
+		Count *uint `notion:"Count"`
+	}{}
+	require.NoError(t, UnmarshalPage(newPage(3), u))
+	assert.Equal(t, uint(3), *u.Count)
+	assert.ErrorIs(t, UnmarshalPage(newPage(-1), u), ErrPropertyTypeMismatch)
+	assert.ErrorIs(t, UnmarshalPage(newPage(1e20), u), ErrPropertyTypeMismatch)
+
+	f := &struct {
+		Count float32 `notion:"Count"`
+	}{}
+	require.NoError(t, UnmarshalPage(newPage(2.5), f))
+	assert.Equal(t, float32(2.5), f.Count)
+	assert.ErrorIs(t, UnmarshalPage(newPage(1e300), f), ErrPropertyTypeMismatch)
+}
+
+func TestMarshalProperties(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]*PropertyMetadata{
+		"Name":     {Type: PropertyTypeTitle},
+		"Note":     {Type: PropertyTypeRichText},
+		"Estimate": {Type: PropertyTypeNumber},
+		"Points":   {Type: PropertyTypeNumber},
+		"Priority": {Type: PropertyTypeSelect},
+		"Tags":     {Type: PropertyTypeMultiSelect},
+		"Due":      {Type: PropertyTypeDate},
+		"Owner":    {Type: PropertyTypePeople},
+		"Parent":   {Type: PropertyTypeRelation},
+		"Done":     {Type: PropertyTypeCheckbox},
+		"Link":     {Type: PropertyTypeURL},
+		"Status":   {Type: PropertyTypeStatus},
+	}
+	points := 3
+	v := task{
+		Name:     "Write docs",
+		Estimate: 2.5,
+		Points:   &points,
+		Priority: "High",
+		Tags:     []string{"docs"},
+		Due:      time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC),
+		Owners:   []string{"u1"},
+		Parent:   []string{"c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1"},
+		Status:   "Done",
+	}
+	properties, err := MarshalProperties(&v, schema)
+	require.NoError(t, err)
+
+	b, err := json.Marshal(properties)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Name": {"type": "title", "title": [{"type": "text", "text": {"content": "Write docs", "link": null}}]},
+		"Estimate": {"type": "number", "number": 2.5},
+		"Points": {"type": "number", "number": 3},
+		"Priority": {"type": "select", "select": {"name": "High"}},
+		"Tags": {"type": "multi_select", "multi_select": [{"name": "docs"}]},
+		"Due": {"type": "date", "date": {"start": "2024-03-01T01:00:00.000Z"}},
+		"Owner": {"type": "people", "people": [{"object": "user", "id": "u1"}]},
+		"Parent": {"type": "relation", "relation": [{"id": "c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1"}]},
+		"Done": {"type": "checkbox", "checkbox": false},
+		"Status": {"type": "status", "status": {"name": "Done"}}
+	}`, string(b))
+
+	// The types are specified by the tag without the schema.
+	properties, err = MarshalProperties(struct {
+		Name string  `notion:"Name,title"`
+		Cost float32 `notion:"Cost,number"`
+	}{Name: "a", Cost: 1.5}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, *properties["Cost"].Number)
+
+	_, err = MarshalProperties(struct {
+		Name string `notion:"Name"`
+	}{}, nil)
+	assert.Error(t, err)
+
+	// The schema of the data source is used since 2025-09-03.
+	ds := &DataSource{Properties: map[string]*PropertyMetadata{"Cost, USD": {Type: PropertyTypeNumber}, "A,B": {Type: PropertyTypeRichText}}}
+	properties, err = MarshalProperties(struct {
+		Cost float64 `notion:"Cost, USD,number"`
+		AB   string  `notion:"A,B"`
+	}{Cost: 3, AB: "x"}, ds.Properties)
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, *properties["Cost, USD"].Number)
+	assert.Equal(t, PropertyTypeRichText, properties["A,B"].Type)
+	_, err = MarshalProperties(struct {
+		Estimate string `notion:"Estimate"`
+	}{Estimate: "a"}, schema)
+	assert.ErrorIs(t, err, ErrPropertyTypeMismatch)
+	_, err = MarshalProperties(struct {
+		Estimate float64 `notion:"Estimate,rich_text"`
+	}{}, schema)
+	assert.ErrorIs(t, err, ErrPropertyTypeMismatch)
+	_, err = MarshalProperties(struct {
+		Unknown string `notion:"Unknown"`
+	}{}, schema)
+	assert.ErrorIs(t, err, ErrPropertyNotFound)
+}
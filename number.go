@@ -0,0 +1,111 @@
+package notion
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The formats of the number property.
+// ref: https://developers.notion.com/reference/property-object#number
+const (
+	NumberFormatNumber           = "number"
+	NumberFormatNumberWithCommas = "number_with_commas"
+	NumberFormatPercent          = "percent"
+	NumberFormatDollar           = "dollar"
+	NumberFormatCanadianDollar   = "canadian_dollar"
+	NumberFormatEuro             = "euro"
+	NumberFormatPound            = "pound"
+	NumberFormatYen              = "yen"
+	NumberFormatRuble            = "ruble"
+	NumberFormatRupee            = "rupee"
+	NumberFormatWon              = "won"
+	NumberFormatYuan             = "yuan"
+	NumberFormatReal             = "real"
+	NumberFormatLira             = "lira"
+	NumberFormatFranc            = "franc"
+	NumberFormatHongKongDollar   = "hong_kong_dollar"
+	NumberFormatNewZealandDollar = "new_zealand_dollar"
+	NumberFormatKrona            = "krona"
+	NumberFormatMexicanPeso      = "mexican_peso"
+	NumberFormatSingaporeDollar  = "singapore_dollar"
+)
+
+type currency struct {
+	Symbol   string
+	Decimals int
+}
+
+var currencies = map[string]currency{
+	NumberFormatDollar:           {Symbol: "$", Decimals: 2},
+	NumberFormatCanadianDollar:   {Symbol: "CA$", Decimals: 2},
+	NumberFormatEuro:             {Symbol: "€", Decimals: 2},
+	NumberFormatPound:            {Symbol: "£", Decimals: 2},
+	NumberFormatYen:              {Symbol: "¥", Decimals: 0},
+	NumberFormatRuble:            {Symbol: "₽", Decimals: 2},
+	NumberFormatRupee:            {Symbol: "₹", Decimals: 2},
+	NumberFormatWon:              {Symbol: "₩", Decimals: 0},
+	NumberFormatYuan:             {Symbol: "CN¥", Decimals: 2},
+	NumberFormatReal:             {Symbol: "R$", Decimals: 2},
+	NumberFormatLira:             {Symbol: "₺", Decimals: 2},
+	NumberFormatFranc:            {Symbol: "CHF ", Decimals: 2},
+	NumberFormatHongKongDollar:   {Symbol: "HK$", Decimals: 2},
+	NumberFormatNewZealandDollar: {Symbol: "NZ$", Decimals: 2},
+	NumberFormatKrona:            {Symbol: "kr ", Decimals: 2},
+	NumberFormatMexicanPeso:      {Symbol: "MX$", Decimals: 2},
+	NumberFormatSingaporeDollar:  {Symbol: "SGD ", Decimals: 2},
+}
+
+// FormatNumber returns the string of v in the format of the number property.
+// The value of the percent format is the ratio (e.g. 0.125 is 12.5%).
+// The currencies which are not known are formatted with commas and two decimals.
+func FormatNumber(v float64, format string) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	switch format {
+	case "", NumberFormatNumber:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case NumberFormatNumberWithCommas:
+		return withCommas(strconv.FormatFloat(v, 'f', -1, 64))
+	case NumberFormatPercent:
+		// Multiplying by 100 makes the error of the binary fraction (e.g. 0.125*100 is 12.500000000000002).
+		s := strconv.FormatFloat(v*100, 'f', 10, 64)
+		f, _ := strconv.ParseFloat(s, 64)
+		return strconv.FormatFloat(f, 'f', -1, 64) + "%"
+	}
+
+	c, ok := currencies[format]
+	if !ok {
+		c = currency{Decimals: 2}
+	}
+	s := withCommas(strconv.FormatFloat(math.Abs(v), 'f', c.Decimals, 64))
+	if v < 0 {
+		return "-" + c.Symbol + s
+	}
+	return c.Symbol + s
+}
+
+// withCommas inserts the thousands separators to the formatted number.
+func withCommas(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i:]
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, c := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	b.WriteString(fraction)
+	return b.String()
+}
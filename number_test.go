@@ -0,0 +1,98 @@
+package notion
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatNumber(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Value  float64
+		Format string
+		Expect string
+	}{
+		{Value: 10, Format: NumberFormatNumber, Expect: "10"},
+		{Value: 12.5, Format: NumberFormatNumber, Expect: "12.5"},
+		{Value: -1234567.25, Format: NumberFormatNumberWithCommas, Expect: "-1,234,567.25"},
+		{Value: 123, Format: NumberFormatNumberWithCommas, Expect: "123"},
+		{Value: 0.125, Format: NumberFormatPercent, Expect: "12.5%"},
+		{Value: 1, Format: NumberFormatPercent, Expect: "100%"},
+		{Value: 1234.5, Format: NumberFormatDollar, Expect: "$1,234.50"},
+		{Value: -0.5, Format: NumberFormatEuro, Expect: "-€0.50"},
+		{Value: 1234.6, Format: NumberFormatYen, Expect: "¥1,235"},
+		{Value: 1000, Format: "baht", Expect: "1,000.00"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.Expect, FormatNumber(tc.Value, tc.Format), "%v %s", tc.Value, tc.Format)
+	}
+}
+
+func TestPropertyData_DecimalNumber(t *testing.T) {
+	t.Parallel()
+
+	page := &Page{}
+	err := json.Unmarshal([]byte(`{
+		"object": "page",
+		"id": "p",
+		"properties": {
+			"Price": {"id": "a", "type": "number", "number": 12.5},
+			"Total": {"id": "b", "type": "formula", "formula": {"type": "number", "number": 0.1}},
+			"Sum": {"id": "c", "type": "rollup", "rollup": {"type": "number", "number": 37.75, "function": "sum"}}
+		}
+	}`), page)
+	require.NoError(t, err)
+	assert.Equal(t, 12.5, *page.Properties["Price"].Number)
+	assert.Equal(t, "12.5", page.Properties["Price"].String())
+	assert.Equal(t, 0.1, *page.Properties["Total"].Formula.Number)
+	assert.Equal(t, 37.75, *page.Properties["Sum"].RollupProperty.Number)
+
+	b, err := json.Marshal(&Filter{Property: "Price", Number: &NumberFilter{GreaterThan: ptr(12.25)}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"property":"Price","number":{"greater_than":12.25}}`, string(b))
+	// The condition with zero is not omitted.
+	b, err = json.Marshal(&Filter{Property: "Price", Number: &NumberFilter{Equals: ptr(0.0)}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"property":"Price","number":{"equals":0}}`, string(b))
+}
+
+func TestPage_FormattedNumber(t *testing.T) {
+	t.Parallel()
+
+	page := &Page{Properties: map[string]*PropertyData{
+		"Rate":  {Type: PropertyTypeNumber, Number: ptr(0.125)},
+		"Price": {Type: PropertyTypeNumber, Number: ptr(1234.5)},
+		"Count": {Type: PropertyTypeNumber, Number: ptr(1234.5)},
+		"Empty": {Type: PropertyTypeNumber},
+		"Name":  {Type: PropertyTypeTitle},
+	}}
+	schema := map[string]*PropertyMetadata{
+		"Rate":  {Type: PropertyTypeNumber, Number: &NumberProperty{Format: NumberFormatPercent}},
+		"Price": {Type: PropertyTypeNumber, Number: &NumberProperty{Format: NumberFormatDollar}},
+		"Empty": {Type: PropertyTypeNumber, Number: &NumberProperty{Format: NumberFormatYen}},
+	}
+
+	cases := []struct {
+		Name   string
+		Expect string
+	}{
+		{Name: "Rate", Expect: "12.5%"},
+		{Name: "Price", Expect: "$1,234.50"},
+		// The property which is not in the schema is the plain number.
+		{Name: "Count", Expect: "1234.5"},
+		{Name: "Empty", Expect: ""},
+	}
+	for _, tc := range cases {
+		s, err := page.FormattedNumber(tc.Name, schema)
+		require.NoError(t, err)
+		assert.Equal(t, tc.Expect, s, tc.Name)
+	}
+
+	_, err := page.FormattedNumber("Name", schema)
+	assert.ErrorIs(t, err, ErrPropertyTypeMismatch)
+}
@@ -0,0 +1,315 @@
+// Package oauth provides helpers for the public integration of Notion.
+// ref: https://developers.notion.com/docs/authorization
+package oauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"go.f110.dev/notion-api/v3"
+)
+
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+)
+
+// Config is a configuration of the public integration.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the URL of the redirect URIs of the integration.
+	// If the integration has multiple redirect URIs, RedirectURL is required.
+	RedirectURL string
+	// BaseURL is the URL of Notion API. The default value is notion.BaseURL.
+	BaseURL string
+	// HTTPClient is used for the request to the token endpoint. The default value is http.DefaultClient.
+	HTTPClient *http.Client
+	// TokenRefreshed is called when TokenSource refreshes the token.
+	// The refresh token is rotated by the refresh, so the new token should be saved for the next start.
+	// If TokenRefreshed returns an error, the request fails and the token will be refreshed again.
+	TokenRefreshed func(ctx context.Context, t *Token) error
+}
+
+// Token is the access token of the integration.
+// In addition to the access token, Token has the information of the workspace and the bot.
+type Token struct {
+	AccessToken          string        `json:"access_token"`
+	TokenType            string        `json:"token_type"`
+	RefreshToken         string        `json:"refresh_token,omitempty"`
+	ExpiresIn            int64         `json:"expires_in,omitempty"`
+	BotID                string        `json:"bot_id"`
+	WorkspaceID          string        `json:"workspace_id"`
+	WorkspaceName        string        `json:"workspace_name,omitempty"`
+	WorkspaceIcon        string        `json:"workspace_icon,omitempty"`
+	Owner                *notion.Owner `json:"owner,omitempty"`
+	DuplicatedTemplateID string        `json:"duplicated_template_id,omitempty"`
+	RequestID            string        `json:"request_id,omitempty"`
+
+	// Expiry is computed from ExpiresIn when the token is issued.
+	// The zero value means the token doesn't expire.
+	Expiry time.Time `json:"expiry"`
+}
+
+// OAuth2 returns the token for golang.org/x/oauth2.
+func (t *Token) OAuth2() *oauth2.Token {
+	tok := &oauth2.Token{
+		AccessToken:  t.AccessToken,
+		TokenType:    t.TokenType,
+		RefreshToken: t.RefreshToken,
+		Expiry:       t.Expiry,
+	}
+	return tok.WithExtra(map[string]interface{}{
+		"bot_id":         t.BotID,
+		"workspace_id":   t.WorkspaceID,
+		"workspace_name": t.WorkspaceName,
+	})
+}
+
+// Introspection is the result of the introspection of the token.
+type Introspection struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Error is the error response of the token endpoint.
+type Error struct {
+	Status      int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+var _ error = &Error{}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+// AuthCodeURL returns the URL of the consent page.
+// state is the value for protecting from CSRF.
+func (c *Config) AuthCodeURL(state string) string {
+	u := c.endpoint("/oauth/authorize")
+	params := url.Values{}
+	params.Set("client_id", c.ClientID)
+	params.Set("response_type", "code")
+	params.Set("owner", "user")
+	if c.RedirectURL != "" {
+		params.Set("redirect_uri", c.RedirectURL)
+	}
+	if state != "" {
+		params.Set("state", state)
+	}
+	u.RawQuery = params.Encode()
+
+	return u.String()
+}
+
+// Exchange converts the authorization code into the token.
+// ref: https://developers.notion.com/reference/create-a-token
+func (c *Config) Exchange(ctx context.Context, code string) (*Token, error) {
+	body := struct {
+		GrantType   string `json:"grant_type"`
+		Code        string `json:"code"`
+		RedirectURI string `json:"redirect_uri,omitempty"`
+	}{
+		GrantType:   GrantTypeAuthorizationCode,
+		Code:        code,
+		RedirectURI: c.RedirectURL,
+	}
+
+	return c.retrieveToken(ctx, body)
+}
+
+// Refresh issues the new token by the refresh token.
+// ref: https://developers.notion.com/reference/refresh-a-token
+func (c *Config) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	body := struct {
+		GrantType    string `json:"grant_type"`
+		RefreshToken string `json:"refresh_token"`
+	}{
+		GrantType:    GrantTypeRefreshToken,
+		RefreshToken: refreshToken,
+	}
+
+	return c.retrieveToken(ctx, body)
+}
+
+// Introspect can get the state of the token.
+// ref: https://developers.notion.com/reference/introspect-token
+func (c *Config) Introspect(ctx context.Context, token string) (*Introspection, error) {
+	body := struct {
+		Token string `json:"token"`
+	}{
+		Token: token,
+	}
+
+	res, err := c.do(ctx, "/oauth/introspect", body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	obj := &Introspection{}
+	if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+		return nil, fmt.Errorf("oauth: failed parse a response: %v", err)
+	}
+
+	return obj, nil
+}
+
+// Revoke revokes the token.
+// ref: https://developers.notion.com/reference/revoke-token
+func (c *Config) Revoke(ctx context.Context, token string) error {
+	body := struct {
+		Token string `json:"token"`
+	}{
+		Token: token,
+	}
+
+	res, err := c.do(ctx, "/oauth/revoke", body)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
+
+// TokenSource returns oauth2.TokenSource which refreshes the token automatically.
+// The returned TokenSource can be used with oauth2.NewClient for notion.New.
+func (c *Config) TokenSource(ctx context.Context, t *Token) oauth2.TokenSource {
+	return oauth2.ReuseTokenSource(t.OAuth2(), &tokenRefresher{ctx: ctx, conf: c, refreshToken: t.RefreshToken})
+}
+
+// Client returns the http client which has the token.
+func (c *Config) Client(ctx context.Context, t *Token) *http.Client {
+	return oauth2.NewClient(ctx, c.TokenSource(ctx, t))
+}
+
+func (c *Config) retrieveToken(ctx context.Context, body interface{}) (*Token, error) {
+	res, err := c.do(ctx, "/oauth/token", body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	obj := &Token{}
+	if err := json.NewDecoder(res.Body).Decode(obj); err != nil {
+		return nil, fmt.Errorf("oauth: failed parse a response: %v", err)
+	}
+	if obj.ExpiresIn > 0 {
+		obj.Expiry = time.Now().Add(time.Duration(obj.ExpiresIn) * time.Second)
+	}
+
+	return obj, nil
+}
+
+// do sends the request with basic authentication.
+// The caller has to close the body of the response.
+func (c *Config) do(ctx context.Context, apiPath string, body interface{}) (*http.Response, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return nil, fmt.Errorf("oauth: failed to encode request body: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(apiPath).String(), buf)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.ClientID, c.ClientSecret)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Notion-Version", notion.Version20220628)
+	req.Header.Add("User-Agent", notion.UserAgent)
+
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	default:
+		defer res.Body.Close()
+		return nil, decodeError(res)
+	}
+
+	return res, nil
+}
+
+func (c *Config) endpoint(apiPath string) *url.URL {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = notion.BaseURL
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		u = &url.URL{Scheme: "https", Host: "api.notion.com"}
+	}
+	u.Path = path.Join("/v1", apiPath)
+
+	return u
+}
+
+func (c *Config) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func decodeError(res *http.Response) error {
+	buf, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	e := &Error{Status: res.StatusCode}
+	if err := json.Unmarshal(buf, e); err == nil && e.Code != "" {
+		return e
+	}
+	// The error object of Notion API
+	apiErr := &notion.Error{}
+	if err := json.Unmarshal(buf, apiErr); err != nil {
+		return err
+	}
+	return apiErr
+}
+
+type tokenRefresher struct {
+	ctx          context.Context
+	conf         *Config
+	refreshToken string
+}
+
+var _ oauth2.TokenSource = (*tokenRefresher)(nil)
+
+func (t *tokenRefresher) Token() (*oauth2.Token, error) {
+	if t.refreshToken == "" {
+		return nil, fmt.Errorf("oauth: token expired and refresh token is not set")
+	}
+
+	tok, err := t.conf.Refresh(t.ctx, t.refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if tok.RefreshToken != "" {
+		t.refreshToken = tok.RefreshToken
+	}
+	if t.conf.TokenRefreshed != nil {
+		if err := t.conf.TokenRefreshed(t.ctx, tok); err != nil {
+			return nil, err
+		}
+	}
+
+	return tok.OAuth2(), nil
+}
@@ -0,0 +1,182 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"go.f110.dev/notion-api/v3"
+)
+
+func TestConfig_AuthCodeURL(t *testing.T) {
+	conf := &Config{ClientID: "client-id", RedirectURL: "https://example.com/callback"}
+
+	u, err := url.Parse(conf.AuthCodeURL("state"))
+	require.NoError(t, err)
+	assert.Equal(t, "api.notion.com", u.Host)
+	assert.Equal(t, "/v1/oauth/authorize", u.Path)
+	assert.Equal(t, "client-id", u.Query().Get("client_id"))
+	assert.Equal(t, "code", u.Query().Get("response_type"))
+	assert.Equal(t, "user", u.Query().Get("owner"))
+	assert.Equal(t, "https://example.com/callback", u.Query().Get("redirect_uri"))
+	assert.Equal(t, "state", u.Query().Get("state"))
+}
+
+func TestConfig_Exchange(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/post-oauth-token.json")
+	require.NoError(t, err)
+	var body map[string]string
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/oauth/token$`),
+		func(req *http.Request) (*http.Response, error) {
+			if u, p, ok := req.BasicAuth(); !ok || u != "client-id" || p != "client-secret" {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, `{"error":"invalid_client"}`), nil
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(http.StatusOK, string(res)), nil
+		},
+	)
+
+	conf := &Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "https://example.com/callback",
+		BaseURL:      "https://example.com",
+		HTTPClient:   &http.Client{Transport: rt},
+	}
+	token, err := conf.Exchange(context.Background(), "code")
+	require.NoError(t, err)
+
+	assert.Equal(t, GrantTypeAuthorizationCode, body["grant_type"])
+	assert.Equal(t, "code", body["code"])
+	assert.Equal(t, "https://example.com/callback", body["redirect_uri"])
+
+	assert.Equal(t, "ntn_access_token", token.AccessToken)
+	assert.Equal(t, "nrt_refresh_token", token.RefreshToken)
+	assert.Equal(t, "b3414d65-9c52-4a2f-a0f1-aa22b1a6e5a6", token.BotID)
+	assert.Equal(t, "e7b3e3a4-3ca0-4d9c-a4e6-2f6e0f0b0a35", token.WorkspaceID)
+	assert.Equal(t, "Test's Notion", token.WorkspaceName)
+	assert.False(t, token.Expiry.IsZero())
+	if assert.NotNil(t, token.Owner) {
+		assert.Equal(t, notion.OwnerTypeUser, token.Owner.Type)
+		if assert.NotNil(t, token.Owner.User) {
+			assert.Equal(t, "Foo Bar", token.Owner.User.Name)
+		}
+	}
+
+	tok := token.OAuth2()
+	assert.Equal(t, "ntn_access_token", tok.AccessToken)
+	assert.Equal(t, "e7b3e3a4-3ca0-4d9c-a4e6-2f6e0f0b0a35", tok.Extra("workspace_id"))
+
+	t.Run("Error", func(t *testing.T) {
+		conf := &Config{
+			ClientID:     "client-id",
+			ClientSecret: "bad-secret",
+			BaseURL:      "https://example.com",
+			HTTPClient:   &http.Client{Transport: rt},
+		}
+		_, err := conf.Exchange(context.Background(), "code")
+		if assert.IsType(t, &Error{}, err) {
+			e := err.(*Error)
+			assert.Equal(t, http.StatusUnauthorized, e.Status)
+			assert.Equal(t, "invalid_client", e.Code)
+		}
+	})
+}
+
+func TestConfig_IntrospectAndRevoke(t *testing.T) {
+	t.Parallel()
+
+	revoked := false
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/oauth/introspect$`),
+		httpmock.NewStringResponder(http.StatusOK, `{"active":true,"scope":"read_content insert_content","iat":1727554061,"request_id":"r"}`),
+	)
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/oauth/revoke$`),
+		func(req *http.Request) (*http.Response, error) {
+			revoked = true
+			return httpmock.NewStringResponse(http.StatusOK, `{"request_id":"r"}`), nil
+		},
+	)
+
+	conf := &Config{ClientID: "client-id", ClientSecret: "client-secret", BaseURL: "https://example.com", HTTPClient: &http.Client{Transport: rt}}
+	i, err := conf.Introspect(context.Background(), "ntn_access_token")
+	require.NoError(t, err)
+	assert.True(t, i.Active)
+	assert.Equal(t, "read_content insert_content", i.Scope)
+	assert.Equal(t, int64(1727554061), i.IssuedAt)
+
+	err = conf.Revoke(context.Background(), "ntn_access_token")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestConfig_TokenSource(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/post-oauth-token.json")
+	require.NoError(t, err)
+	var body map[string]string
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/oauth/token$`),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(http.StatusOK, string(res)), nil
+		},
+	)
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/users/me$`),
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Authorization") != "Bearer ntn_access_token" {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, `{"object":"error","status":401,"code":"unauthorized","message":""}`), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{"object":"user","id":"b3414d65-9c52-4a2f-a0f1-aa22b1a6e5a6","type":"bot","name":"Bot","bot":{}}`), nil
+		},
+	)
+
+	var refreshed *Token
+	conf := &Config{ClientID: "client-id", ClientSecret: "client-secret", BaseURL: "https://example.com", HTTPClient: &http.Client{Transport: rt}}
+	conf.TokenRefreshed = func(_ context.Context, t *Token) error {
+		refreshed = t
+		return nil
+	}
+	expired := &Token{AccessToken: "expired", RefreshToken: "old_refresh_token", Expiry: time.Now().Add(-time.Minute)}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: rt})
+
+	client, err := notion.New(conf.Client(ctx, expired), "https://example.com")
+	require.NoError(t, err)
+	user, err := client.GetUser(ctx, "me")
+	require.NoError(t, err)
+	assert.Equal(t, "Bot", user.Name)
+	assert.Equal(t, GrantTypeRefreshToken, body["grant_type"])
+	assert.Equal(t, "old_refresh_token", body["refresh_token"])
+	if assert.NotNil(t, refreshed) {
+		assert.Equal(t, "ntn_access_token", refreshed.AccessToken)
+		assert.Equal(t, "nrt_refresh_token", refreshed.RefreshToken)
+	}
+}
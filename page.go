@@ -4,14 +4,33 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 )
 
 func NewPage(db *Database, title string, children []*Block) (*Page, error) {
 	if db.ID == "" {
 		return nil, errors.New("notion: not specified parent database")
 	}
+
+	return newPage(&PageParent{DatabaseID: db.ID, Database: db}, db.Properties, title, children)
+}
+
+// NewPageInDataSource returns the page which belongs to the data source.
+// The data source is available since 2025-09-03.
+func NewPageInDataSource(ds *DataSource, title string, children []*Block) (*Page, error) {
+	if ds.ID == "" {
+		return nil, errors.New("notion: not specified parent data source")
+	}
+
+	return newPage(&PageParent{Type: ObjectTypeDataSourceID, DataSourceID: ds.ID, DataSource: ds}, ds.Properties, title, children)
+}
+
+func newPage(parent *PageParent, properties map[string]*PropertyMetadata, title string, children []*Block) (*Page, error) {
 	var titleID string
-	for _, prop := range db.Properties {
+	for _, prop := range properties {
 		if prop.Type != "title" {
 			continue
 		}
@@ -22,21 +41,11 @@ func NewPage(db *Database, title string, children []*Block) (*Page, error) {
 	}
 
 	return &Page{
-		Parent: &PageParent{
-			DatabaseID: db.ID,
-			Database:   db,
-		},
+		Parent: parent,
 		Properties: map[string]*PropertyData{
 			titleID: {
-				Type: "title",
-				Title: []*RichTextObject{
-					{
-						Type: "text",
-						Text: &Text{
-							Content: title,
-						},
-					},
-				},
+				Type:  "title",
+				Title: RichText().Text(title).Build(),
 			},
 		},
 		Children: children,
@@ -44,12 +53,21 @@ func NewPage(db *Database, title string, children []*Block) (*Page, error) {
 }
 
 func (p *Page) SetProperty(key string, value *PropertyData) {
-	if p.Parent == nil || p.Parent.Database == nil {
+	if p.Parent == nil {
+		return
+	}
+	var properties map[string]*PropertyMetadata
+	switch {
+	case p.Parent.Database != nil:
+		properties = p.Parent.Database.Properties
+	case p.Parent.DataSource != nil:
+		properties = p.Parent.DataSource.Properties
+	default:
 		return
 	}
 
 	var schema *PropertyMetadata
-	for k, v := range p.Parent.Database.Properties {
+	for k, v := range properties {
 		if k == key {
 			schema = v
 			break
@@ -65,6 +83,43 @@ func (p *Page) SetProperty(key string, value *PropertyData) {
 	p.Properties[key] = value
 }
 
+// checkSchema checks that all properties which have the value in the page exist in schema with the same type.
+// The title property is not checked because every database has it.
+func (p *Page) checkSchema(schema map[string]*PropertyMetadata) error {
+	var mismatches []string
+	for k, v := range p.Properties {
+		if v.Type == PropertyTypeTitle || v.isEmpty() {
+			continue
+		}
+
+		s, ok := schema[k]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("%s is not found", k))
+		case s.Type != v.Type:
+			mismatches = append(mismatches, fmt.Sprintf("%s is %s, not %s", k, s.Type, v.Type))
+		}
+	}
+	if len(mismatches) > 0 {
+		sort.Strings(mismatches)
+		return fmt.Errorf("%w: %s", ErrSchemaMismatch, strings.Join(mismatches, ", "))
+	}
+
+	return nil
+}
+
+// HasExpiredFile reports whether the files properties of the page have the file which URL is expired at t.
+func (p *Page) HasExpiredFile(t time.Time) bool {
+	for _, v := range p.Properties {
+		for _, f := range v.Files {
+			if f.Expired(t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (p *Page) New() *Page {
 	buf := new(bytes.Buffer)
 	if err := json.NewEncoder(buf).Encode(p); err != nil {
@@ -120,6 +175,10 @@ func (p *Page) New() *Page {
 			if v.Select == nil {
 				delete(newPage.Properties, k)
 			}
+		case PropertyTypeStatus:
+			if v.Status == nil {
+				delete(newPage.Properties, k)
+			}
 		case PropertyTypeMultiSelect:
 			if len(v.MultiSelect) == 0 {
 				delete(newPage.Properties, k)
@@ -152,3 +211,196 @@ func (p *Page) New() *Page {
 
 	return newPage
 }
+
+var (
+	// ErrPropertyNotFound is returned by the accessors of Page when the page doesn't have the property.
+	ErrPropertyNotFound = errors.New("notion: property not found")
+	// ErrPropertyTypeMismatch is returned by the accessors of Page when the type of the property is different.
+	ErrPropertyTypeMismatch = errors.New("notion: property type mismatch")
+)
+
+// property returns the property which has the name and the type.
+func (p *Page) property(name string, t PropertyType) (*PropertyData, error) {
+	v, ok := p.Properties[name]
+	if !ok || v == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPropertyNotFound, name)
+	}
+	if v.Type != t {
+		return nil, fmt.Errorf("%w: %s is %s, not %s", ErrPropertyTypeMismatch, name, v.Type, t)
+	}
+	return v, nil
+}
+
+// Title returns the plain text of the title property.
+func (p *Page) Title() (string, error) {
+	for _, v := range p.Properties {
+		if v != nil && v.Type == PropertyTypeTitle {
+			return plainText(v.Title), nil
+		}
+	}
+	return "", fmt.Errorf("%w: title", ErrPropertyNotFound)
+}
+
+// Text returns the plain text of the rich text property.
+func (p *Page) Text(name string) (string, error) {
+	v, err := p.property(name, PropertyTypeRichText)
+	if err != nil {
+		return "", err
+	}
+	return plainText(v.RichText), nil
+}
+
+// Number returns the value of the number property. If the property is empty, Number returns 0.
+func (p *Page) Number(name string) (float64, error) {
+	v, err := p.property(name, PropertyTypeNumber)
+	if err != nil {
+		return 0, err
+	}
+	if v.Number == nil {
+		return 0, nil
+	}
+	return *v.Number, nil
+}
+
+// FormattedNumber returns the value of the number property in the format of the property in schema (e.g. "$1,234.50" or "12.5%").
+// schema is the properties of the database (or the data source since 2025-09-03).
+// If schema doesn't have the format of the property, the value is formatted as the plain number.
+// If the property is empty, FormattedNumber returns the empty string.
+func (p *Page) FormattedNumber(name string, schema map[string]*PropertyMetadata) (string, error) {
+	v, err := p.property(name, PropertyTypeNumber)
+	if err != nil {
+		return "", err
+	}
+	if v.Number == nil {
+		return "", nil
+	}
+	format := NumberFormatNumber
+	if meta, ok := schema[name]; ok && meta != nil && meta.Number != nil {
+		format = meta.Number.Format
+	}
+	return FormatNumber(*v.Number, format), nil
+}
+
+// Date returns the value of the date property. If the property is empty, Date returns nil.
+func (p *Page) Date(name string) (*DateProperty, error) {
+	v, err := p.property(name, PropertyTypeDate)
+	if err != nil {
+		return nil, err
+	}
+	return v.Date, nil
+}
+
+// Select returns the name of the selected option. If the property is empty, Select returns the empty string.
+func (p *Page) Select(name string) (string, error) {
+	v, err := p.property(name, PropertyTypeSelect)
+	if err != nil {
+		return "", err
+	}
+	if v.Select == nil {
+		return "", nil
+	}
+	return v.Select.Name, nil
+}
+
+// MultiSelect returns the names of the selected options.
+func (p *Page) MultiSelect(name string) ([]string, error) {
+	v, err := p.property(name, PropertyTypeMultiSelect)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(v.MultiSelect))
+	for _, o := range v.MultiSelect {
+		names = append(names, o.Name)
+	}
+	return names, nil
+}
+
+// Status returns the name of the status. If the property is empty, Status returns the empty string.
+func (p *Page) Status(name string) (string, error) {
+	v, err := p.property(name, PropertyTypeStatus)
+	if err != nil {
+		return "", err
+	}
+	if v.Status == nil {
+		return "", nil
+	}
+	return v.Status.Name, nil
+}
+
+// Checkbox returns the value of the checkbox property.
+func (p *Page) Checkbox(name string) (bool, error) {
+	v, err := p.property(name, PropertyTypeCheckbox)
+	if err != nil {
+		return false, err
+	}
+	return v.Checkbox, nil
+}
+
+// URLProperty returns the value of the url property.
+// The name is not URL because Page.URL is the URL of the page.
+func (p *Page) URLProperty(name string) (string, error) {
+	v, err := p.property(name, PropertyTypeURL)
+	if err != nil {
+		return "", err
+	}
+	return v.URL, nil
+}
+
+// Email returns the value of the email property.
+func (p *Page) Email(name string) (string, error) {
+	v, err := p.property(name, PropertyTypeEmail)
+	if err != nil {
+		return "", err
+	}
+	return v.Email, nil
+}
+
+// PhoneNumber returns the value of the phone_number property.
+func (p *Page) PhoneNumber(name string) (string, error) {
+	v, err := p.property(name, PropertyTypePhoneNumber)
+	if err != nil {
+		return "", err
+	}
+	return v.PhoneNumber, nil
+}
+
+// Relations returns the IDs of the related pages.
+// The relation property which has more than 25 relations is truncated by the API. Use GetPageProperty to get all of them.
+func (p *Page) Relations(name string) ([]string, error) {
+	v, err := p.property(name, PropertyTypeRelation)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(v.Relation))
+	for _, r := range v.Relation {
+		ids = append(ids, r.ID)
+	}
+	return ids, nil
+}
+
+// People returns the users of the people property.
+func (p *Page) People(name string) ([]*User, error) {
+	v, err := p.property(name, PropertyTypePeople)
+	if err != nil {
+		return nil, err
+	}
+	return v.People, nil
+}
+
+// Files returns the files of the files property.
+func (p *Page) Files(name string) ([]*File, error) {
+	v, err := p.property(name, PropertyTypeFiles)
+	if err != nil {
+		return nil, err
+	}
+	return v.Files, nil
+}
+
+// UniqueID returns the value of the unique_id property.
+func (p *Page) UniqueID(name string) (*UniqueID, error) {
+	v, err := p.property(name, PropertyTypeUniqueID)
+	if err != nil {
+		return nil, err
+	}
+	return v.UniqueID, nil
+}
@@ -2,6 +2,7 @@ package notion
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -42,3 +43,58 @@ func TestPage_New(t *testing.T) {
 	assert.NotContains(t, newPage.Properties, "Test18")
 	assert.Empty(t, newPage.URL)
 }
+
+func TestPage_Accessors(t *testing.T) {
+	t.Parallel()
+
+	page := &Page{}
+	err := json.Unmarshal([]byte(`{
+		"object": "page",
+		"id": "56f2049d-feb1-4a3f-b227-2fa76ca74d0e",
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": [{"type": "text", "text": {"content": "Write "}, "plain_text": "Write "}, {"type": "text", "text": {"content": "docs"}, "plain_text": "docs"}]},
+			"Estimate": {"id": "a", "type": "number", "number": 2.5},
+			"Due": {"id": "b", "type": "date", "date": {"start": "2024-03-01", "end": null, "time_zone": null}},
+			"Priority": {"id": "c", "type": "select", "select": {"id": "o1", "name": "High", "color": "red"}},
+			"Parent": {"id": "d", "type": "relation", "relation": [{"id": "c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1"}], "has_more": false},
+			"Owner": {"id": "e", "type": "people", "people": [{"object": "user", "id": "u1", "name": "Postmaster"}]},
+			"Done": {"id": "f", "type": "checkbox", "checkbox": true},
+			"Empty": {"id": "g", "type": "number", "number": null}
+		}
+	}`), page)
+	require.NoError(t, err)
+
+	title, err := page.Title()
+	require.NoError(t, err)
+	assert.Equal(t, "Write docs", title)
+	estimate, err := page.Number("Estimate")
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, estimate)
+	empty, err := page.Number("Empty")
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, empty)
+	due, err := page.Date("Due")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-01", due.String())
+	priority, err := page.Select("Priority")
+	require.NoError(t, err)
+	assert.Equal(t, "High", priority)
+	parent, err := page.Relations("Parent")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1"}, parent)
+	owner, err := page.People("Owner")
+	require.NoError(t, err)
+	if assert.Len(t, owner, 1) {
+		assert.Equal(t, "Postmaster", owner[0].Name)
+	}
+	done, err := page.Checkbox("Done")
+	require.NoError(t, err)
+	assert.True(t, done)
+
+	_, err = page.Number("Unknown")
+	assert.ErrorIs(t, err, ErrPropertyNotFound)
+	_, err = page.Number("Priority")
+	assert.ErrorIs(t, err, ErrPropertyTypeMismatch)
+	_, err = (&Page{}).Title()
+	assert.ErrorIs(t, err, ErrPropertyNotFound)
+}
@@ -0,0 +1,302 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// DefaultRateLimit is the average number of requests per second which is allowed for each integration.
+	// ref: https://developers.notion.com/reference/request-limits
+	DefaultRateLimit = 3
+	// DefaultIdleTimeout is the duration until the unused client is evicted from the pool.
+	DefaultIdleTimeout = 30 * time.Minute
+)
+
+// TokenSourceFunc returns the token source for the workspace or the bot.
+// TokenSourceFunc is called when the client of the pool sends the first request.
+type TokenSourceFunc func(ctx context.Context, id string) (oauth2.TokenSource, error)
+
+// ClientPool manages the clients for multiple workspaces.
+// The clients share the underlying transport and each token has its own rate limiter.
+type ClientPool struct {
+	baseURL     string
+	tokenSource TokenSourceFunc
+	transport   http.RoundTripper
+	clientOpts  []ClientOpt
+	rateLimit   float64
+	burst       int
+	idleTimeout time.Duration
+	nowFunc     func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*pooledClient
+	limiters  map[string]*rateLimiter
+	lastSweep time.Time
+}
+
+type ClientPoolOpt func(*ClientPool)
+
+// WithPoolTransport specifies the transport which is shared by all clients.
+// The default transport is http.DefaultTransport.
+func WithPoolTransport(rt http.RoundTripper) ClientPoolOpt {
+	return func(p *ClientPool) {
+		p.transport = rt
+	}
+}
+
+// WithPoolRateLimit specifies the number of requests per second and the burst size for each token.
+func WithPoolRateLimit(rps float64, burst int) ClientPoolOpt {
+	return func(p *ClientPool) {
+		p.rateLimit = rps
+		p.burst = burst
+	}
+}
+
+// WithPoolIdleTimeout specifies the duration until the unused client is evicted.
+func WithPoolIdleTimeout(d time.Duration) ClientPoolOpt {
+	return func(p *ClientPool) {
+		p.idleTimeout = d
+	}
+}
+
+// WithPoolClientOpts specifies the options for each client.
+func WithPoolClientOpts(opts ...ClientOpt) ClientPoolOpt {
+	return func(p *ClientPool) {
+		p.clientOpts = append(p.clientOpts, opts...)
+	}
+}
+
+type pooledClient struct {
+	client   *Client
+	lastUsed time.Time
+}
+
+func NewClientPool(baseURL string, tokenSource TokenSourceFunc, opts ...ClientPoolOpt) (*ClientPool, error) {
+	if tokenSource == nil {
+		return nil, errors.New("notion: TokenSourceFunc is required")
+	}
+
+	p := &ClientPool{
+		baseURL:     baseURL,
+		tokenSource: tokenSource,
+		transport:   http.DefaultTransport,
+		rateLimit:   DefaultRateLimit,
+		burst:       DefaultRateLimit,
+		idleTimeout: DefaultIdleTimeout,
+		nowFunc:     time.Now,
+		clients:     make(map[string]*pooledClient),
+		limiters:    make(map[string]*rateLimiter),
+	}
+	for _, v := range opts {
+		v(p)
+	}
+	// Validate the base URL at once because For can't return an error.
+	if _, err := New(nil, baseURL, p.clientOpts...); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// For returns the client for the workspace or the bot.
+// The client is created lazily and is cached until it is evicted.
+func (p *ClientPool) For(id string) *Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.nowFunc()
+	p.sweep(now)
+	if c, ok := p.clients[id]; ok && !p.isIdle(c, now) {
+		c.lastUsed = now
+		return c.client
+	}
+
+	t := &poolTransport{pool: p, id: id}
+	// The error is checked in NewClientPool
+	client, _ := New(&http.Client{Transport: t}, p.baseURL, p.clientOpts...)
+	p.clients[id] = &pooledClient{client: client, lastUsed: now}
+	return client
+}
+
+// Evict removes the client for the workspace or the bot.
+func (p *ClientPool) Evict(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, id)
+}
+
+// Len returns the number of the cached clients.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.clients)
+}
+
+// sweep evicts idle clients and limiters. The caller has to hold the lock.
+func (p *ClientPool) sweep(now time.Time) {
+	if p.idleTimeout <= 0 || now.Sub(p.lastSweep) < p.idleTimeout/2 {
+		return
+	}
+	p.lastSweep = now
+
+	for k, v := range p.clients {
+		if p.isIdle(v, now) {
+			delete(p.clients, k)
+		}
+	}
+	for k, v := range p.limiters {
+		if now.Sub(v.lastUsed()) > p.idleTimeout {
+			delete(p.limiters, k)
+		}
+	}
+}
+
+func (p *ClientPool) isIdle(c *pooledClient, now time.Time) bool {
+	return p.idleTimeout > 0 && now.Sub(c.lastUsed) > p.idleTimeout
+}
+
+func (p *ClientPool) touch(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.clients[id]; ok {
+		c.lastUsed = p.nowFunc()
+	}
+}
+
+func (p *ClientPool) limiter(token string) *rateLimiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if l, ok := p.limiters[token]; ok {
+		return l
+	}
+	l := newRateLimiter(p.rateLimit, p.burst, p.nowFunc)
+	p.limiters[token] = l
+	return l
+}
+
+type poolTransport struct {
+	pool *ClientPool
+	id   string
+
+	mu sync.Mutex
+	ts oauth2.TokenSource
+}
+
+var _ http.RoundTripper = (*poolTransport)(nil)
+
+func (t *poolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ts, err := t.tokenSource(req.Context())
+	if err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+	tok, err := ts.Token()
+	if err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+	if err := t.pool.limiter(tok.AccessToken).Wait(req.Context()); err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+	t.pool.touch(t.id)
+
+	r := req.Clone(req.Context())
+	tok.SetAuthHeader(r)
+	return t.pool.transport.RoundTrip(r)
+}
+
+// closeRequestBody closes the body of the request which is not sent.
+// RoundTripper must close the body even on errors.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+func (t *poolTransport) tokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.ts != nil {
+		return t.ts, nil
+	}
+	ts, err := t.pool.tokenSource(ctx, t.id)
+	if err != nil {
+		return nil, fmt.Errorf("notion: failed to get the token source for %s: %w", t.id, err)
+	}
+	t.ts = oauth2.ReuseTokenSource(nil, ts)
+	return t.ts, nil
+}
+
+// rateLimiter is a token bucket.
+type rateLimiter struct {
+	interval time.Duration
+	burst    float64
+	nowFunc  func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rps float64, burst int, nowFunc func() time.Time) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	var interval time.Duration
+	if rps > 0 {
+		interval = time.Duration(float64(time.Second) / rps)
+	}
+	return &rateLimiter{interval: interval, burst: float64(burst), tokens: float64(burst), last: nowFunc(), nowFunc: nowFunc}
+}
+
+// Wait blocks until the request is allowed.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		now := l.nowFunc()
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) * float64(l.interval))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (l *rateLimiter) lastUsed() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.last
+}
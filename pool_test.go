@@ -0,0 +1,133 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestClientPool(t *testing.T) {
+	t.Parallel()
+
+	res, err := os.ReadFile("./testdata/get-page.json")
+	require.NoError(t, err)
+	var mu sync.Mutex
+	var tokens []string
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/pages/[a-z0-9-]{36}`),
+		func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			tokens = append(tokens, req.Header.Get("Authorization"))
+			mu.Unlock()
+			return httpmock.NewStringResponse(http.StatusOK, string(res)), nil
+		},
+	)
+	tokenSource := func(_ context.Context, id string) (oauth2.TokenSource, error) {
+		if id == "unknown" {
+			return nil, errors.New("not found")
+		}
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token-" + id}), nil
+	}
+
+	t.Run("For", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := NewClientPool("https://example.com", tokenSource, WithPoolTransport(rt), WithPoolRateLimit(0, 0))
+		require.NoError(t, err)
+
+		c1 := pool.For("workspace-1")
+		assert.Same(t, c1, pool.For("workspace-1"))
+		c2 := pool.For("workspace-2")
+		assert.NotSame(t, c1, c2)
+		assert.Equal(t, 2, pool.Len())
+
+		_, err = c1.GetPage(context.Background(), "16493215-50a8-41b8-8b43-0a0c014a7910")
+		require.NoError(t, err)
+		_, err = c2.GetPage(context.Background(), "16493215-50a8-41b8-8b43-0a0c014a7910")
+		require.NoError(t, err)
+		mu.Lock()
+		assert.Contains(t, tokens, "Bearer token-workspace-1")
+		assert.Contains(t, tokens, "Bearer token-workspace-2")
+		mu.Unlock()
+
+		_, err = pool.For("unknown").GetPage(context.Background(), "16493215-50a8-41b8-8b43-0a0c014a7910")
+		assert.Error(t, err)
+	})
+
+	t.Run("Evict", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := NewClientPool("https://example.com", tokenSource, WithPoolTransport(rt), WithPoolIdleTimeout(time.Minute))
+		require.NoError(t, err)
+		now := time.Now()
+		pool.nowFunc = func() time.Time { return now }
+
+		c1 := pool.For("workspace-1")
+		now = now.Add(50 * time.Second)
+		pool.For("workspace-2")
+		now = now.Add(20 * time.Second)
+		assert.NotSame(t, c1, pool.For("workspace-1"))
+		assert.Equal(t, 2, pool.Len())
+
+		pool.Evict("workspace-2")
+		assert.Equal(t, 1, pool.Len())
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := NewClientPool("https://example.com", tokenSource, WithPoolTransport(rt), WithPoolRateLimit(20, 1))
+		require.NoError(t, err)
+
+		client := pool.For("workspace-3")
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			_, err := client.GetPage(context.Background(), "16493215-50a8-41b8-8b43-0a0c014a7910")
+			require.NoError(t, err)
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = client.GetPage(ctx, "16493215-50a8-41b8-8b43-0a0c014a7910")
+		assert.Error(t, err)
+	})
+
+	t.Run("CloseBody", func(t *testing.T) {
+		t.Parallel()
+
+		pool, err := NewClientPool("https://example.com", tokenSource, WithPoolTransport(rt), WithPoolRateLimit(0, 0))
+		require.NoError(t, err)
+
+		body := &closeRecorder{Reader: strings.NewReader("{}")}
+		req, err := http.NewRequest(http.MethodPost, "https://example.com/v1/pages", body)
+		require.NoError(t, err)
+		_, err = pool.For("unknown").httpClient.Transport.RoundTrip(req)
+		assert.Error(t, err)
+		assert.True(t, body.closed)
+	})
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
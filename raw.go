@@ -0,0 +1,298 @@
+package notion
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// knownFieldsCache is the cache of the JSON field names of the types.
+var knownFieldsCache sync.Map
+
+// knownFields returns the JSON field names and the types of the struct including the fields of the embedded structs.
+func knownFields(t reflect.Type) map[string]reflect.Type {
+	if v, ok := knownFieldsCache.Load(t); ok {
+		return v.(map[string]reflect.Type)
+	}
+
+	fields := make(map[string]reflect.Type)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name := strings.Split(tag, ",")[0]
+			if f.Anonymous && name == "" {
+				walk(f.Type)
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			fields[name] = f.Type
+		}
+	}
+	walk(t)
+	knownFieldsCache.Store(t, fields)
+
+	return fields
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// nestedStruct returns the struct type of the field if the unknown fields of the object can be found by the fields of the struct.
+// The type which decodes itself (e.g. DateProperty) is not the target because the JSON doesn't always match the fields.
+func nestedStruct(t reflect.Type) (reflect.Type, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType || reflect.PtrTo(t).Implements(unmarshalerType) {
+		return nil, false
+	}
+	return t, true
+}
+
+// decodeUnknownFields returns the fields of the object which are not defined in t.
+// The unknown fields in the object of the known field are kept as the object under the name of the known field
+// (e.g. {"paragraph":{"new_field":1}}). The elements of the arrays are not inspected.
+// The fields in ignore are not kept even if they are unknown (e.g. the fields which are owned by the API).
+//
+// data has to be the valid JSON which is already decoded to t. So data is scanned without decoding the values.
+func decodeUnknownFields(data []byte, t reflect.Type, ignore map[string]struct{}) (map[string]json.RawMessage, error) {
+	s := &rawScanner{data: data}
+	return s.unknownFields(t, ignore)
+}
+
+// encodeUnknownFields adds the unknown fields to the encoded object.
+// The fields which are already in data are not overwritten, but the objects are merged.
+// If t is not nil, the unknown fields in the known field of t are dropped when the known field is not in data
+// because the known field was cleared after decoding.
+func encodeUnknownFields(data []byte, t reflect.Type, unknown map[string]json.RawMessage) ([]byte, error) {
+	if len(unknown) == 0 {
+		return data, nil
+	}
+
+	m := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	var known map[string]reflect.Type
+	if t != nil {
+		known = knownFields(t)
+	}
+	for k, v := range unknown {
+		cur, ok := m[k]
+		if !ok {
+			if _, isKnown := known[k]; !isKnown {
+				m[k] = v
+			}
+			continue
+		}
+		if !isObject(cur) || !isObject(v) {
+			continue
+		}
+		nested := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(v, &nested); err != nil {
+			return nil, err
+		}
+		var ft reflect.Type
+		if f, isKnown := known[k]; isKnown {
+			ft, _ = nestedStruct(f)
+		}
+		merged, err := encodeUnknownFields(cur, ft, nested)
+		if err != nil {
+			return nil, err
+		}
+		m[k] = merged
+	}
+
+	return json.Marshal(m)
+}
+
+func isObject(b json.RawMessage) bool {
+	for _, c := range b {
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return c == '{'
+	}
+	return false
+}
+
+var errInvalidJSON = errors.New("notion: invalid JSON")
+
+// rawScanner walks the valid JSON without decoding the values.
+type rawScanner struct {
+	data []byte
+	pos  int
+}
+
+// unknownFields reads the object and returns the fields which are not defined in t.
+func (s *rawScanner) unknownFields(t reflect.Type, ignore map[string]struct{}) (map[string]json.RawMessage, error) {
+	known := knownFields(t)
+	if err := s.expect('{'); err != nil {
+		return nil, err
+	}
+
+	var unknown map[string]json.RawMessage
+	add := func(key []byte, v json.RawMessage) error {
+		k := string(key)
+		if strings.IndexByte(k, '\\') >= 0 {
+			if err := json.Unmarshal(append(append([]byte{'"'}, key...), '"'), &k); err != nil {
+				return err
+			}
+		}
+		if unknown == nil {
+			unknown = make(map[string]json.RawMessage)
+		}
+		unknown[k] = v
+		return nil
+	}
+	for {
+		s.skipSpace()
+		if s.peek() == '}' {
+			s.pos++
+			return unknown, nil
+		}
+		key, err := s.readString()
+		if err != nil {
+			return nil, err
+		}
+		if err := s.expect(':'); err != nil {
+			return nil, err
+		}
+		s.skipSpace()
+
+		start := s.pos
+		if ft, ok := known[string(key)]; ok {
+			nt, nested := nestedStruct(ft)
+			if nested && s.peek() == '{' {
+				m, err := s.unknownFields(nt, nil)
+				if err != nil {
+					return nil, err
+				}
+				if len(m) > 0 {
+					b, err := json.Marshal(m)
+					if err != nil {
+						return nil, err
+					}
+					if err := add(key, b); err != nil {
+						return nil, err
+					}
+				}
+			} else if err := s.skipValue(); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := s.skipValue(); err != nil {
+				return nil, err
+			}
+			if _, ok := ignore[string(key)]; !ok {
+				// data may be reused by the caller, so the value is copied.
+				if err := add(key, append(json.RawMessage(nil), s.data[start:s.pos]...)); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		s.skipSpace()
+		if s.peek() == ',' {
+			s.pos++
+		}
+	}
+}
+
+func (s *rawScanner) skipSpace() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\r', '\n':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *rawScanner) peek() byte {
+	if s.pos >= len(s.data) {
+		return 0
+	}
+	return s.data[s.pos]
+}
+
+func (s *rawScanner) expect(c byte) error {
+	s.skipSpace()
+	if s.peek() != c {
+		return errInvalidJSON
+	}
+	s.pos++
+	return nil
+}
+
+// readString returns the content of the string without unquoting.
+func (s *rawScanner) readString() ([]byte, error) {
+	if err := s.expect('"'); err != nil {
+		return nil, err
+	}
+	start := s.pos
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case '\\':
+			s.pos += 2
+		case '"':
+			s.pos++
+			return s.data[start : s.pos-1], nil
+		default:
+			s.pos++
+		}
+	}
+	return nil, errInvalidJSON
+}
+
+func (s *rawScanner) skipValue() error {
+	s.skipSpace()
+	switch s.peek() {
+	case '"':
+		_, err := s.readString()
+		return err
+	case '{', '[':
+		depth := 0
+		for s.pos < len(s.data) {
+			switch s.data[s.pos] {
+			case '"':
+				if _, err := s.readString(); err != nil {
+					return err
+				}
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+			s.pos++
+			if depth == 0 {
+				return nil
+			}
+		}
+		return errInvalidJSON
+	default:
+		// The number, true, false and null.
+		for s.pos < len(s.data) {
+			switch s.data[s.pos] {
+			case ',', '}', ']', ' ', '\t', '\r', '\n':
+				return nil
+			}
+			s.pos++
+		}
+		return nil
+	}
+}
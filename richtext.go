@@ -0,0 +1,187 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NewPageMention returns the rich text which mentions the page.
+func NewPageMention(pageID string) *RichTextObject {
+	return &RichTextObject{
+		Type:    RichTextObjectTypeMention,
+		Mention: &Mention{Type: MentionTypePage, Page: &Meta{ID: pageID}},
+	}
+}
+
+// NewDatabaseMention returns the rich text which mentions the database.
+func NewDatabaseMention(databaseID string) *RichTextObject {
+	return &RichTextObject{
+		Type:    RichTextObjectTypeMention,
+		Mention: &Mention{Type: MentionTypeDatabase, Database: &Meta{ID: databaseID}},
+	}
+}
+
+// NewDateMention returns the rich text which mentions the date.
+// Use NewDate or NewDateTime for the start and the end of the date.
+func NewDateMention(date *DateProperty) *RichTextObject {
+	return &RichTextObject{
+		Type:    RichTextObjectTypeMention,
+		Mention: &Mention{Type: MentionTypeDate, Date: date},
+	}
+}
+
+const (
+	// MaxTextContentLength is the maximum length of the content of the text object.
+	MaxTextContentLength = 2000
+	// MaxRichTextElements is the maximum number of the rich text objects in the array.
+	MaxRichTextElements = 100
+)
+
+var ErrTooManyRichTextElements = errors.New("notion: too many rich text elements")
+
+// RichTextBuilder builds the rich text objects.
+// The text which is longer than MaxTextContentLength is split into multiple text objects.
+//
+//	objs := notion.RichText().Text("a").Bold("b").Link("c", url).MentionPage(id).Equation("x^2").Build()
+type RichTextBuilder struct {
+	objs []*RichTextObject
+}
+
+// RichText returns the new builder.
+func RichText() *RichTextBuilder {
+	return &RichTextBuilder{}
+}
+
+// Text appends the plain text.
+func (b *RichTextBuilder) Text(s string) *RichTextBuilder {
+	return b.text(s, nil, nil)
+}
+
+// Bold appends the bold text.
+func (b *RichTextBuilder) Bold(s string) *RichTextBuilder {
+	return b.text(s, nil, &TextAnnotation{Bold: true, Color: "default"})
+}
+
+// Italic appends the italic text.
+func (b *RichTextBuilder) Italic(s string) *RichTextBuilder {
+	return b.text(s, nil, &TextAnnotation{Italic: true, Color: "default"})
+}
+
+// Strikethrough appends the strikethrough text.
+func (b *RichTextBuilder) Strikethrough(s string) *RichTextBuilder {
+	return b.text(s, nil, &TextAnnotation{Strikethrough: true, Color: "default"})
+}
+
+// Underline appends the underlined text.
+func (b *RichTextBuilder) Underline(s string) *RichTextBuilder {
+	return b.text(s, nil, &TextAnnotation{Underline: true, Color: "default"})
+}
+
+// Code appends the inline code.
+func (b *RichTextBuilder) Code(s string) *RichTextBuilder {
+	return b.text(s, nil, &TextAnnotation{Code: true, Color: "default"})
+}
+
+// Color appends the colored text. color is the name of the color (e.g. red, blue_background).
+func (b *RichTextBuilder) Color(s, color string) *RichTextBuilder {
+	return b.text(s, nil, &TextAnnotation{Color: color})
+}
+
+// Styled appends the text which has the annotations.
+func (b *RichTextBuilder) Styled(s string, annotations *TextAnnotation) *RichTextBuilder {
+	return b.text(s, nil, annotations)
+}
+
+// Link appends the text which links to url.
+func (b *RichTextBuilder) Link(s, url string) *RichTextBuilder {
+	return b.text(s, &Link{URL: url}, nil)
+}
+
+// MentionPage appends the mention of the page.
+func (b *RichTextBuilder) MentionPage(pageID string) *RichTextBuilder {
+	b.objs = append(b.objs, NewPageMention(pageID))
+	return b
+}
+
+// MentionDatabase appends the mention of the database.
+func (b *RichTextBuilder) MentionDatabase(databaseID string) *RichTextBuilder {
+	b.objs = append(b.objs, NewDatabaseMention(databaseID))
+	return b
+}
+
+// MentionDate appends the mention of the date.
+func (b *RichTextBuilder) MentionDate(date *DateProperty) *RichTextBuilder {
+	b.objs = append(b.objs, NewDateMention(date))
+	return b
+}
+
+// Equation appends the inline equation. expression is the KaTeX compatible string.
+func (b *RichTextBuilder) Equation(expression string) *RichTextBuilder {
+	b.objs = append(b.objs, &RichTextObject{Type: RichTextObjectTypeEquation, Equation: &Equation{Expression: expression}})
+	return b
+}
+
+// Build returns the rich text objects.
+// Build doesn't fail even if the number of the objects exceeds MaxRichTextElements. Check Err before sending them.
+func (b *RichTextBuilder) Build() []*RichTextObject {
+	objs := make([]*RichTextObject, len(b.objs))
+	copy(objs, b.objs)
+	return objs
+}
+
+// Err returns ErrTooManyRichTextElements if the number of the objects exceeds MaxRichTextElements.
+// The API rejects the array which has too many elements.
+func (b *RichTextBuilder) Err() error {
+	if len(b.objs) > MaxRichTextElements {
+		return fmt.Errorf("%w: %d elements, the limit is %d", ErrTooManyRichTextElements, len(b.objs), MaxRichTextElements)
+	}
+	return nil
+}
+
+func (b *RichTextBuilder) text(s string, link *Link, annotations *TextAnnotation) *RichTextBuilder {
+	for _, v := range splitText(s, MaxTextContentLength) {
+		obj := &RichTextObject{Type: RichTextObjectTypeText, Text: &Text{Content: v, Link: link}}
+		if annotations != nil {
+			a := *annotations
+			obj.Annotations = &a
+		}
+		b.objs = append(b.objs, obj)
+	}
+	return b
+}
+
+// splitText splits s into the chunks which length is at most n.
+// The length is counted in UTF-16 code units as the API does, and the surrogate pair is never split.
+func splitText(s string, n int) []string {
+	var chunks []string
+	start, length := 0, 0
+	for i, r := range s {
+		// The rune outside the basic multilingual plane is the surrogate pair.
+		l := 1
+		if r > 0xFFFF {
+			l = 2
+		}
+		if length+l > n {
+			chunks = append(chunks, s[start:i])
+			start, length = i, 0
+		}
+		length += l
+	}
+	return append(chunks, s[start:])
+}
+
+// plainText returns the concatenated text of objs.
+// The object which is not retrieved from the API doesn't have PlainText. In that case, the content of the text is used.
+func plainText(objs []*RichTextObject) string {
+	var b strings.Builder
+	for _, v := range objs {
+		switch {
+		case v.PlainText != "":
+			b.WriteString(v.PlainText)
+		case v.Text != nil:
+			b.WriteString(v.Text.Content)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package notion
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMention(t *testing.T) {
+	t.Parallel()
+
+	objs := []*RichTextObject{}
+	err := json.Unmarshal([]byte(`[
+		{"type": "mention", "mention": {"type": "date", "date": {"start": "2024-03-01", "end": "2024-03-03", "time_zone": null}}, "plain_text": "2024-03-01 → 2024-03-03"},
+		{"type": "mention", "mention": {"type": "link_preview", "link_preview": {"url": "https://github.com/f110/notion-api/pull/1"}}, "plain_text": "https://github.com/f110/notion-api/pull/1"},
+		{"type": "mention", "mention": {"type": "link_mention", "link_mention": {"href": "https://example.com", "title": "Example", "icon_url": "https://example.com/favicon.ico"}}, "plain_text": "Example"},
+		{"type": "mention", "mention": {"type": "template_mention", "template_mention": {"type": "template_mention_date", "template_mention_date": "today"}}, "plain_text": "@Today"},
+		{"type": "mention", "mention": {"type": "custom_emoji", "custom_emoji": {"id": "e1", "name": "party", "url": "https://example.com/party.png"}}, "plain_text": ":party:"}
+	]`), &objs)
+	require.NoError(t, err)
+	require.Len(t, objs, 5)
+
+	if m := objs[0].Mention; assert.NotNil(t, m.Date) {
+		assert.Equal(t, "2024-03-01", m.Date.Start.String())
+		assert.Equal(t, "2024-03-03", m.Date.End.String())
+	}
+	if m := objs[1].Mention; assert.NotNil(t, m.LinkPreview) {
+		assert.Equal(t, "https://github.com/f110/notion-api/pull/1", m.LinkPreview.URL)
+	}
+	if m := objs[2].Mention; assert.NotNil(t, m.LinkMention) {
+		assert.Equal(t, "https://example.com", m.LinkMention.Href)
+		assert.Equal(t, "Example", m.LinkMention.Title)
+	}
+	if m := objs[3].Mention; assert.NotNil(t, m.TemplateMention) {
+		assert.Equal(t, TemplateMentionTypeDate, m.TemplateMention.Type)
+		assert.Equal(t, "today", m.TemplateMention.TemplateMentionDate)
+	}
+	if m := objs[4].Mention; assert.NotNil(t, m.CustomEmoji) {
+		assert.Equal(t, "party", m.CustomEmoji.Name)
+	}
+}
+
+func TestNewMention(t *testing.T) {
+	t.Parallel()
+
+	b, err := json.Marshal(NewPageMention("56f2049d-feb1-4a3f-b227-2fa76ca74d0e"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"mention","mention":{"type":"page","page":{"id":"56f2049d-feb1-4a3f-b227-2fa76ca74d0e"}}}`, string(b))
+
+	b, err = json.Marshal(NewDateMention(&DateProperty{Start: NewDateTime(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"mention","mention":{"type":"date","date":{"start":"2024-03-01T10:00:00.000Z"}}}`, string(b))
+}
+
+func TestRichTextBuilder(t *testing.T) {
+	t.Parallel()
+
+	b := RichText().Text("a").Bold("b").Link("c", "https://example.com").MentionPage("56f2049d-feb1-4a3f-b227-2fa76ca74d0e").Equation("x^2")
+	require.NoError(t, b.Err())
+	objs := b.Build()
+	require.Len(t, objs, 5)
+	assert.Equal(t, "a", objs[0].Text.Content)
+	assert.Nil(t, objs[0].Annotations)
+	assert.True(t, objs[1].Annotations.Bold)
+	assert.Equal(t, "https://example.com", objs[2].Text.Link.URL)
+	assert.Equal(t, MentionTypePage, objs[3].Mention.Type)
+	assert.Equal(t, "x^2", objs[4].Equation.Expression)
+
+	buf, err := json.Marshal(objs[2])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"text","text":{"content":"c","link":{"url":"https://example.com"}}}`, string(buf))
+
+	// The long text is split.
+	long := strings.Repeat("a", MaxTextContentLength) + strings.Repeat("😀", 3)
+	objs = RichText().Italic(long).Build()
+	require.Len(t, objs, 2)
+	assert.Equal(t, strings.Repeat("a", MaxTextContentLength), objs[0].Text.Content)
+	assert.Equal(t, strings.Repeat("😀", 3), objs[1].Text.Content)
+	assert.True(t, objs[1].Annotations.Italic)
+	// The surrogate pair is not split.
+	objs = RichText().Text(strings.Repeat("a", MaxTextContentLength-1) + "😀").Build()
+	require.Len(t, objs, 2)
+	assert.Equal(t, "😀", objs[1].Text.Content)
+
+	// The number of the elements exceeds the limit.
+	b = RichText().Text(strings.Repeat("a", MaxTextContentLength*MaxRichTextElements+1))
+	assert.Len(t, b.Build(), MaxRichTextElements+1)
+	assert.ErrorIs(t, b.Err(), ErrTooManyRichTextElements)
+}
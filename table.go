@@ -0,0 +1,93 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// NewTable returns the table block which has rows as the content.
+// The width of the table is the length of the longest row, and the shorter rows are filled with the empty cells.
+// The text of the cell longer than MaxTextContentLength is split into multiple rich text objects.
+// If hasColumnHeader is true, the first row is the header.
+func NewTable(rows [][]string, hasColumnHeader, hasRowHeader bool) (*Block, error) {
+	width := 0
+	for _, v := range rows {
+		if len(v) > width {
+			width = len(v)
+		}
+	}
+	if width == 0 {
+		return nil, errors.New("notion: the table must have at least one cell")
+	}
+
+	children := make([]*Block, 0, len(rows))
+	for r, row := range rows {
+		cells := make([][]*RichTextObject, width)
+		for i := range cells {
+			cells[i] = []*RichTextObject{}
+		}
+		for i, v := range row {
+			if v == "" {
+				continue
+			}
+			b := RichText().Text(v)
+			if err := b.Err(); err != nil {
+				return nil, fmt.Errorf("notion: the cell %d of the row %d is too long: %w", i, r, err)
+			}
+			cells[i] = b.Build()
+		}
+		children = append(children, &Block{Type: BlockTypeTableRow, TableRow: &TableRow{Cells: cells}})
+	}
+
+	return &Block{
+		Type: BlockTypeTable,
+		Table: &Table{
+			TableWidth:      width,
+			HasColumnHeader: hasColumnHeader,
+			HasRowHeader:    hasRowHeader,
+			Children:        children,
+		},
+	}, nil
+}
+
+// TableGrid returns the plain text of the cells of rows.
+// rows are the children of the table block. The blocks which are not table_row are returned as error.
+func TableGrid(rows []*Block) ([][]string, error) {
+	grid := make([][]string, 0, len(rows))
+	for _, v := range rows {
+		if v.Type != BlockTypeTableRow || v.TableRow == nil {
+			return nil, fmt.Errorf("notion: %s is not table_row", v.Type)
+		}
+
+		row := make([]string, len(v.TableRow.Cells))
+		for i, cell := range v.TableRow.Cells {
+			row[i] = plainText(cell)
+		}
+		grid = append(grid, row)
+	}
+
+	return grid, nil
+}
+
+// GetTable returns the table block and the plain text of the cells.
+func (c *Client) GetTable(ctx context.Context, blockID string) (*Block, [][]string, error) {
+	block, err := c.GetBlock(ctx, blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if block.Type != BlockTypeTable {
+		return nil, nil, fmt.Errorf("notion: %s is not table: %s", blockID, block.Type)
+	}
+
+	rows, err := c.GetBlocks(ctx, blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grid, err := TableGrid(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return block, grid, nil
+}
@@ -0,0 +1,146 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTable(t *testing.T) {
+	t.Parallel()
+
+	block, err := NewTable([][]string{{"Name", "Count"}, {"Apple", "3"}, {"Orange"}}, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, BlockTypeTable, block.Type)
+	assert.Equal(t, 2, block.Table.TableWidth)
+	assert.True(t, block.Table.HasColumnHeader)
+	assert.False(t, block.Table.HasRowHeader)
+	require.Len(t, block.Table.Children, 3)
+	for _, v := range block.Table.Children {
+		assert.Equal(t, BlockTypeTableRow, v.Type)
+		assert.Len(t, v.TableRow.Cells, 2)
+	}
+	// The empty cell is encoded as the empty array.
+	buf, err := json.Marshal(block.Table.Children[2])
+	require.NoError(t, err)
+	assert.Contains(t, string(buf), `"cells":[[{"type":"text","text":{"content":"Orange","link":null}}],[]]`)
+
+	grid, err := TableGrid(block.Table.Children)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Count"}, {"Apple", "3"}, {"Orange", ""}}, grid)
+
+	// The long text is split into multiple rich text objects.
+	block, err = NewTable([][]string{{strings.Repeat("a", 4500)}}, false, false)
+	require.NoError(t, err)
+	cell := block.Table.Children[0].TableRow.Cells[0]
+	require.Len(t, cell, 3)
+	assert.Len(t, cell[0].Text.Content, MaxTextContentLength)
+	assert.Len(t, cell[2].Text.Content, 500)
+	_, err = NewTable([][]string{{strings.Repeat("a", (MaxRichTextElements+1)*MaxTextContentLength)}}, false, false)
+	assert.ErrorIs(t, err, ErrTooManyRichTextElements)
+
+	_, err = NewTable(nil, false, false)
+	assert.Error(t, err)
+	_, err = TableGrid([]*Block{{Type: BlockTypeParagraph, Paragraph: &Paragraph{}}})
+	assert.Error(t, err)
+}
+
+func TestGetTable(t *testing.T) {
+	t.Parallel()
+
+	table, err := NewTable([][]string{{"Name", "Count"}, {"Apple", "3"}}, true, false)
+	require.NoError(t, err)
+	rows := table.Table.Children
+	table.Table.Children = nil
+	table.Meta = &Meta{Object: ObjectTypeBlock, ID: "5b1ed4b2-0c66-4b4c-9d3a-6a2f3e9c1a01"}
+	table.HasChildren = true
+	for i, v := range rows {
+		v.Meta = &Meta{Object: ObjectTypeBlock, ID: strings.Repeat(string(rune('a'+i)), 8) + "-0000-0000-0000-000000000000"}
+		// The response has the plain text.
+		for _, cell := range v.TableRow.Cells {
+			for _, t := range cell {
+				t.PlainText = t.Text.Content
+			}
+		}
+	}
+
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/blocks/[a-z0-9-]{36}$`),
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, table),
+	)
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/blocks/[a-z0-9-]{36}/children`),
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, &BlockList{ListMeta: &ListMeta{Object: ObjectTypeList}, Results: rows}),
+	)
+	client, err := New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+
+	block, grid, err := client.GetTable(context.Background(), table.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, block.Table.TableWidth)
+	assert.True(t, block.Table.HasColumnHeader)
+	assert.Equal(t, [][]string{{"Name", "Count"}, {"Apple", "3"}}, grid)
+}
+
+func TestCopyBlocks_Table(t *testing.T) {
+	t.Parallel()
+
+	table, err := NewTable([][]string{{"Name", "Count"}, {"Apple", "3"}}, true, false)
+	require.NoError(t, err)
+	rows := table.Table.Children
+	table.Table.Children = nil
+	table.Meta = &Meta{Object: ObjectTypeBlock, ID: "5b1ed4b2-0c66-4b4c-9d3a-6a2f3e9c1a01"}
+	table.HasChildren = true
+	tree := map[string][]*Block{
+		"c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1": {table},
+		table.ID:                               rows,
+	}
+
+	var appended []*Block
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/blocks/[a-z0-9-]{36}/children`),
+		func(req *http.Request) (*http.Response, error) {
+			id := strings.Split(req.URL.Path, "/")[3]
+			return httpmock.NewJsonResponse(http.StatusOK, &BlockList{ListMeta: &ListMeta{Object: ObjectTypeList}, Results: tree[id]})
+		},
+	)
+	rt.RegisterRegexpResponder(
+		http.MethodPatch,
+		regexp.MustCompile(`/v1/blocks/[a-z0-9-]{36}/children`),
+		func(req *http.Request) (*http.Response, error) {
+			body := struct {
+				Children []*Block `json:"children"`
+			}{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			appended = append(appended, body.Children...)
+			for _, v := range body.Children {
+				v.Meta = &Meta{Object: ObjectTypeBlock, ID: "00000001-0000-0000-0000-000000000000"}
+			}
+			return httpmock.NewJsonResponse(http.StatusOK, &BlockList{ListMeta: &ListMeta{Object: ObjectTypeList}, Results: body.Children})
+		},
+	)
+	client, err := New(&http.Client{Transport: rt}, "https://example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, client.CopyBlocks(context.Background(), "c6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1", "d6f20d3a-b5a9-4c2f-9fd3-9b1b7bdcb2a1"))
+	// The rows are created with the table by one request.
+	require.Len(t, appended, 1)
+	assert.Equal(t, BlockTypeTable, appended[0].Type)
+	grid, err := TableGrid(appended[0].Table.Children)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Count"}, {"Apple", "3"}}, grid)
+}
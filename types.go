@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"reflect"
 	"strings"
 	"time"
 )
@@ -14,12 +15,17 @@ type Object interface{}
 type ObjectType string
 
 const (
-	ObjectTypeDatabase   ObjectType = "database"
-	ObjectTypeDatabaseID ObjectType = "database_id"
-	ObjectTypePage       ObjectType = "page"
-	ObjectTypeBlock      ObjectType = "block"
-	ObjectTypeList       ObjectType = "list"
-	ObjectTypeUser       ObjectType = "user"
+	ObjectTypeDatabase     ObjectType = "database"
+	ObjectTypeDatabaseID   ObjectType = "database_id"
+	ObjectTypeDataSource   ObjectType = "data_source"
+	ObjectTypeDataSourceID ObjectType = "data_source_id"
+	ObjectTypePage         ObjectType = "page"
+	ObjectTypePageID       ObjectType = "page_id"
+	ObjectTypeWorkspace    ObjectType = "workspace"
+	ObjectTypeBlock        ObjectType = "block"
+	ObjectTypeList         ObjectType = "list"
+	ObjectTypeUser         ObjectType = "user"
+	ObjectTypeFileUpload   ObjectType = "file_upload"
 )
 
 type Meta struct {
@@ -83,14 +89,14 @@ type User struct {
 	*Meta
 
 	// Type of the user.
-	Type UserType `json:"type"`
+	Type UserType `json:"type,omitempty"`
 	// Displayed name
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
 	// Avatar image url
-	AvatarURL string `json:"avatar_url"`
+	AvatarURL string `json:"avatar_url,omitempty"`
 
-	Person *Person `json:"person"`
-	Bot    *Bot    `json:"bot"`
+	Person *Person `json:"person,omitempty"`
+	Bot    *Bot    `json:"bot,omitempty"`
 }
 
 func (u *User) String() string {
@@ -108,21 +114,73 @@ type PartialUser struct {
 	*Meta
 }
 
+const (
+	dateLayout          = "2006-01-02"
+	dateTimeLayout      = "2006-01-02T15:04:05.000Z07:00"
+	localDateTimeLayout = "2006-01-02T15:04:05.000"
+)
+
+// Date is the date or the date-time of the date property and the date filter.
+// If HasTime is false, the value is the date only and the time of the day is ignored.
 type Date struct {
 	time.Time
+	HasTime bool
+
+	// floating is true if the date-time doesn't have UTC offset.
+	// The floating date-time is the wall clock in the time zone of DateProperty.
+	floating bool
+}
+
+// NewDate returns the date which doesn't have the time.
+func NewDate(t time.Time) *Date {
+	return &Date{Time: t}
+}
+
+// NewDateTime returns the date which has the time.
+func NewDateTime(t time.Time) *Date {
+	return &Date{Time: t, HasTime: true}
 }
 
 func (d *Date) UnmarshalJSON(data []byte) error {
-	t, err := time.Parse("\"2006-01-02\"", string(data))
-	if err != nil {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
-	d.Time = t
+
+	if len(s) == len(dateLayout) {
+		t, err := time.Parse(dateLayout, s)
+		if err != nil {
+			return err
+		}
+		d.Time, d.HasTime, d.floating = t, false, false
+		return nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		d.Time, d.HasTime, d.floating = t, true, false
+		return nil
+	}
+	// The date-time of the date property which has the time zone doesn't have UTC offset.
+	t, err := time.Parse("2006-01-02T15:04:05.999999999", s)
+	if err != nil {
+		return fmt.Errorf("notion: invalid date: %s", s)
+	}
+	d.Time, d.HasTime, d.floating = t, true, true
 	return nil
 }
 
 func (d Date) MarshalJSON() ([]byte, error) {
-	return []byte("\"" + d.Time.Format("2006-01-02") + "\""), nil
+	return json.Marshal(d.String())
+}
+
+// String returns the date in ISO 8601 format.
+func (d Date) String() string {
+	if !d.HasTime {
+		return d.Time.Format(dateLayout)
+	}
+	return d.Time.Format(dateTimeLayout)
 }
 
 type Person struct {
@@ -145,6 +203,7 @@ const (
 type Owner struct {
 	Type      OwnerType `json:"type"`
 	Workspace bool      `json:"workspace"`
+	User      *User     `json:"user,omitempty"`
 }
 
 type UserList struct {
@@ -193,22 +252,61 @@ const (
 	MentionTypeDatabase    MentionType = "database"
 	MentionTypeDate        MentionType = "date"
 	MentionTypeLinkPreview MentionType = "link_preview"
+	MentionTypeLinkMention MentionType = "link_mention"
+	MentionTypeTemplate    MentionType = "template_mention"
+	MentionTypeCustomEmoji MentionType = "custom_emoji"
 )
 
+// Mention is the content of the mention rich text.
+// ref: https://developers.notion.com/reference/rich-text#mention
 type Mention struct {
 	Type MentionType `json:"type"`
 
-	User     *User `json:"user,omitempty"`
-	Page     *Meta `json:"page,omitempty"`
-	Database *Meta `json:"database,omitempty"`
+	User            *User            `json:"user,omitempty"`
+	Page            *Meta            `json:"page,omitempty"`
+	Database        *Meta            `json:"database,omitempty"`
+	Date            *DateProperty    `json:"date,omitempty"`
+	LinkPreview     *LinkPreview     `json:"link_preview,omitempty"`
+	LinkMention     *LinkMention     `json:"link_mention,omitempty"`
+	TemplateMention *TemplateMention `json:"template_mention,omitempty"`
+	CustomEmoji     *CustomEmoji     `json:"custom_emoji,omitempty"`
 }
 
+// LinkMention is the URL which is shown as the mention with the metadata of the page.
+type LinkMention struct {
+	Href         string `json:"href"`
+	Title        string `json:"title,omitempty"`
+	Description  string `json:"description,omitempty"`
+	LinkAuthor   string `json:"link_author,omitempty"`
+	LinkProvider string `json:"link_provider,omitempty"`
+	IconURL      string `json:"icon_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
+type TemplateMentionType string
+
+const (
+	TemplateMentionTypeDate TemplateMentionType = "template_mention_date"
+	TemplateMentionTypeUser TemplateMentionType = "template_mention_user"
+)
+
+// TemplateMention is the mention in the template which is replaced when the template is duplicated.
+type TemplateMention struct {
+	Type TemplateMentionType `json:"type"`
+	// TemplateMentionDate is "today" or "now".
+	TemplateMentionDate string `json:"template_mention_date,omitempty"`
+	// TemplateMentionUser is "me".
+	TemplateMentionUser string `json:"template_mention_user,omitempty"`
+}
+
+// Equation is the content of the equation block and the inline equation.
 type Equation struct {
+	// Expression is the KaTeX compatible string.
 	Expression string `json:"expression"`
 }
 
 type Link struct {
-	Type string `json:"type"`
+	Type string `json:"type,omitempty"`
 	URL  string `json:"url"`
 }
 
@@ -227,6 +325,9 @@ type Database struct {
 	IsInline       bool                         `json:"is_inline,omitempty"`
 	PublicURL      string                       `json:"public_url,omitempty"`
 	Archived       bool                         `json:"archived,omitempty"`
+	// DataSources is the list of the data sources which belongs to the database.
+	// This field is only available since 2025-09-03.
+	DataSources []*DataSourceReference `json:"data_sources,omitempty"`
 }
 
 func (d *Database) decode() error {
@@ -246,6 +347,42 @@ type DatabaseList struct {
 	Results []*Database `json:"results"`
 }
 
+type DataSourceReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// DataSource is a data source object.
+// ref: https://developers.notion.com/reference/data-source
+type DataSource struct {
+	*Meta
+
+	Parent         *PageParent                  `json:"parent,omitempty"`
+	DatabaseParent *PageParent                  `json:"database_parent,omitempty"`
+	CreatedTime    Time                         `json:"created_time,omitempty"`
+	CreatedBy      *PartialUser                 `json:"created_by,omitempty"`
+	LastEditedTime Time                         `json:"last_edited_time,omitempty"`
+	LastEditedBy   *PartialUser                 `json:"last_edited_by,omitempty"`
+	Title          []*RichTextObject            `json:"title"`
+	Description    []*RichTextObject            `json:"description,omitempty"`
+	Properties     map[string]*PropertyMetadata `json:"properties"`
+	URL            string                       `json:"url,omitempty"`
+	PublicURL      string                       `json:"public_url,omitempty"`
+	Archived       bool                         `json:"archived,omitempty"`
+}
+
+func (d *DataSource) decode() error {
+	for _, v := range d.Properties {
+		id, err := url.QueryUnescape(v.ID)
+		if err != nil {
+			return err
+		}
+		v.ID = id
+	}
+
+	return nil
+}
+
 type PropertyMetadata struct {
 	ID   string       `json:"id,omitempty"`
 	Type PropertyType `json:"type,omitempty"`
@@ -256,6 +393,7 @@ type PropertyMetadata struct {
 	Number         *NumberProperty      `json:"number,omitempty"`
 	Select         *SelectProperty      `json:"select,omitempty"`
 	MultiSelect    *MultiSelectProperty `json:"multi_select,omitempty"`
+	Status         *StatusProperty      `json:"status,omitempty"`
 	Date           *struct{}            `json:"date,omitempty"`
 	Formula        *struct{}            `json:"formula,omitempty"`
 	Relation       *struct{}            `json:"relation,omitempty"`
@@ -270,6 +408,7 @@ type PropertyMetadata struct {
 	CreatedBy      *struct{}            `json:"created_by,omitempty"`
 	LastEditedTime *struct{}            `json:"last_edited_time,omitempty"`
 	LastEditedBy   *struct{}            `json:"last_edited_by,omitempty"`
+	UniqueID       *UniqueIDProperty    `json:"unique_id,omitempty"`
 }
 
 func (p *PropertyMetadata) String() string {
@@ -284,11 +423,19 @@ func (p *PropertyMetadata) String() string {
 		b.WriteString(fmt.Sprintf("%v", p.Number))
 	case PropertyTypeSelect:
 		b.WriteString(fmt.Sprintf("%v", p.Select))
+	case PropertyTypeStatus:
+		b.WriteString(fmt.Sprintf("%v", p.Status))
 	}
 
 	return b.String()
 }
 
+// UniqueIDProperty is the schema of the unique_id property.
+type UniqueIDProperty struct {
+	// Prefix is the prefix of the ID (e.g. TASK of TASK-15). Prefix is empty if the ID doesn't have the prefix.
+	Prefix string `json:"prefix,omitempty"`
+}
+
 type NumberProperty struct {
 	Format string `json:"format"`
 }
@@ -301,6 +448,45 @@ type MultiSelectProperty struct {
 	Options []*Option `json:"options"`
 }
 
+// StatusProperty is the configuration of the status property.
+// The options of the status property can't be updated by the API.
+type StatusProperty struct {
+	Options []*Option      `json:"options,omitempty"`
+	Groups  []*StatusGroup `json:"groups,omitempty"`
+}
+
+// Group returns the group which the option belongs to. name is the name of the option.
+// If the option is not found, Group returns nil.
+func (p *StatusProperty) Group(name string) *StatusGroup {
+	var id string
+	for _, v := range p.Options {
+		if v.Name == name {
+			id = v.ID
+			break
+		}
+	}
+	if id == "" {
+		return nil
+	}
+
+	for _, g := range p.Groups {
+		for _, v := range g.OptionIDs {
+			if v == id {
+				return g
+			}
+		}
+	}
+	return nil
+}
+
+// StatusGroup is the group of the options of the status property (e.g. To-do, In progress and Complete).
+type StatusGroup struct {
+	ID        string   `json:"id,omitempty"`
+	Name      string   `json:"name"`
+	Color     string   `json:"color,omitempty"`
+	OptionIDs []string `json:"option_ids"`
+}
+
 type Option struct {
 	ID    string `json:"id,omitempty"`
 	Name  string `json:"name,omitempty"`
@@ -309,7 +495,98 @@ type Option struct {
 
 type DateProperty struct {
 	Start *Date `json:"start,omitempty"`
-	End   *Date `json:"end,omitempty"`
+	// End is not nil if the date is the range.
+	End *Date `json:"end,omitempty"`
+	// TimeZone is the name of the time zone in the IANA database (e.g. Asia/Tokyo).
+	// If TimeZone is specified, Start and End are the time in the time zone.
+	// If the time zone can't be loaded (e.g. the system doesn't have tzdata), Start and End keep the wall clock of the time zone in UTC.
+	TimeZone string `json:"time_zone,omitempty"`
+}
+
+// IsRange reports whether the date has the end.
+func (p *DateProperty) IsRange() bool {
+	return p.End != nil
+}
+
+// String returns the start and the end in ISO 8601 format.
+func (p *DateProperty) String() string {
+	if p.Start == nil {
+		return ""
+	}
+	if p.End == nil {
+		return p.Start.String()
+	}
+	return p.Start.String() + " → " + p.End.String()
+}
+
+func (p *DateProperty) UnmarshalJSON(b []byte) error {
+	type dateProperty DateProperty
+	if err := json.Unmarshal(b, (*dateProperty)(p)); err != nil {
+		return err
+	}
+	if p.TimeZone == "" {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		// Keep the raw time zone and the wall clock so that the value can be written back as it is.
+		return nil
+	}
+	for _, v := range []*Date{p.Start, p.End} {
+		if v == nil || !v.HasTime {
+			continue
+		}
+		if v.floating {
+			v.Time = time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), v.Nanosecond(), loc)
+			v.floating = false
+		} else {
+			v.Time = v.Time.In(loc)
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the date-time without UTC offset if TimeZone is specified.
+// The API doesn't accept the date-time which has UTC offset with the time zone.
+func (p *DateProperty) MarshalJSON() ([]byte, error) {
+	type dateProperty DateProperty
+	if p.TimeZone == "" {
+		return json.Marshal((*dateProperty)(p))
+	}
+
+	loc, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		// The floating date-time is the wall clock in the time zone. It can be encoded without the location.
+		for _, v := range []*Date{p.Start, p.End} {
+			if v != nil && v.HasTime && !v.floating {
+				return nil, fmt.Errorf("notion: unknown time zone %s: %v", p.TimeZone, err)
+			}
+		}
+	}
+	format := func(d *Date) *string {
+		if d == nil {
+			return nil
+		}
+		s := d.String()
+		switch {
+		case d.floating:
+			s = d.Time.Format(localDateTimeLayout)
+		case d.HasTime:
+			s = d.Time.In(loc).Format(localDateTimeLayout)
+		}
+		return &s
+	}
+	return json.Marshal(struct {
+		*dateProperty
+		Start *string `json:"start,omitempty"`
+		End   *string `json:"end,omitempty"`
+	}{
+		dateProperty: (*dateProperty)(p),
+		Start:        format(p.Start),
+		End:          format(p.End),
+	})
 }
 
 type FormulaType string
@@ -325,7 +602,7 @@ type FormulaProperty struct {
 	Type FormulaType `json:"type"`
 
 	String  string        `json:"string,omitempty"`
-	Number  int           `json:"number,omitempty"`
+	Number  *float64      `json:"number,omitempty"`
 	Boolean bool          `json:"boolean,omitempty"`
 	Date    *DateProperty `json:"date,omitempty"`
 }
@@ -368,7 +645,7 @@ const (
 type Rollup struct {
 	Type RollupType `json:"type"`
 
-	Number *NumberProperty `json:"number,omitempty"`
+	Number *float64        `json:"number,omitempty"`
 	Date   *DateProperty   `json:"date,omitempty"`
 	Array  []*PropertyData `json:"array,omitempty"`
 }
@@ -385,6 +662,8 @@ type Page struct {
 	Properties     map[string]*PropertyData `json:"properties"`
 	Children       []*Block                 `json:"children,omitempty"`
 	URL            string                   `json:"url,omitempty"`
+	// Template is used only when creating the page. Template and Children can't be specified at the same time.
+	Template *PageTemplate `json:"template,omitempty"`
 }
 
 func (p *Page) decode() error {
@@ -405,12 +684,58 @@ type PageList struct {
 }
 
 type PageParent struct {
-	Type       ObjectType `json:"type,omitempty"`
-	DatabaseID string     `json:"database_id,omitempty"`
-	PageID     string     `json:"page_id,omitempty"`
+	Type         ObjectType `json:"type,omitempty"`
+	DatabaseID   string     `json:"database_id,omitempty"`
+	DataSourceID string     `json:"data_source_id,omitempty"`
+	PageID       string     `json:"page_id,omitempty"`
+	Workspace    bool       `json:"workspace,omitempty"`
+
+	Database   *Database   `json:"-"`
+	DataSource *DataSource `json:"-"`
+	Page       *Page       `json:"-"`
+}
+
+// resolveDataSource rewrites the parent database to the data source.
+// Since 2025-09-03, a page can't be created under the database directly.
+// The parent will be rewritten only if the database has a single data source.
+func (p *PageParent) resolveDataSource() {
+	if p == nil || p.DataSourceID != "" || p.DatabaseID == "" {
+		return
+	}
+	if p.Database == nil || len(p.Database.DataSources) != 1 {
+		return
+	}
+
+	p.Type = ObjectTypeDataSourceID
+	p.DataSourceID = p.Database.DataSources[0].ID
+	p.DatabaseID = ""
+}
+
+type TemplateType string
+
+const (
+	TemplateTypeNone       TemplateType = "none"
+	TemplateTypeDefault    TemplateType = "default"
+	TemplateTypeTemplateID TemplateType = "template_id"
+)
 
-	Database *Database `json:"-"`
-	Page     *Page     `json:"-"`
+// PageTemplate specifies the template which is applied to the new page.
+// The template is available since 2025-09-03.
+type PageTemplate struct {
+	Type       TemplateType `json:"type"`
+	TemplateID string       `json:"template_id,omitempty"`
+}
+
+// Template is the template of the data source.
+type Template struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"is_default"`
+}
+
+type TemplateList struct {
+	*ListMeta
+	Templates []*Template `json:"templates"`
 }
 
 type PropertyType string
@@ -422,6 +747,7 @@ const (
 	PropertyTypeNumber         PropertyType = "number"
 	PropertyTypeSelect         PropertyType = "select"
 	PropertyTypeMultiSelect    PropertyType = "multi_select"
+	PropertyTypeStatus         PropertyType = "status"
 	PropertyTypeDate           PropertyType = "date"
 	PropertyTypePeople         PropertyType = "people"
 	PropertyTypeFiles          PropertyType = "files"
@@ -447,8 +773,9 @@ type PropertyData struct {
 	MultiSelect    []*Option         `json:"multi_select,omitempty"`
 	Text           []*RichTextObject `json:"text,omitempty"`
 	RichText       []*RichTextObject `json:"rich_text,omitempty"`
-	Number         *int              `json:"number,omitempty"`
+	Number         *float64          `json:"number,omitempty"`
 	Select         *Option           `json:"select,omitempty"`
+	Status         *Option           `json:"status,omitempty"`
 	Date           *DateProperty     `json:"date,omitempty"`
 	People         []*User           `json:"people,omitempty"`
 	Files          []*File           `json:"files,omitempty"`
@@ -464,6 +791,96 @@ type PropertyData struct {
 	LastEditedTime *Time             `json:"last_edited_time,omitempty"`
 	LastEditedBy   *User             `json:"last_edited_by,omitempty"`
 	UniqueID       *UniqueID         `json:"unique_id,omitempty"`
+
+	// UnknownFields has the fields which are not supported by this package (e.g. the value of the new property type).
+	// The unknown fields in the value of the known type are kept as the object under the name of the type.
+	// UnknownFields is encoded with the property as is.
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+func (d *PropertyData) UnmarshalJSON(b []byte) error {
+	type propertyData PropertyData
+	if err := json.Unmarshal(b, (*propertyData)(d)); err != nil {
+		return err
+	}
+	unknown, err := decodeUnknownFields(b, reflect.TypeOf(propertyData{}), nil)
+	if err != nil {
+		return err
+	}
+	d.UnknownFields = unknown
+
+	return nil
+}
+
+func (d *PropertyData) MarshalJSON() ([]byte, error) {
+	type propertyData PropertyData
+	b, err := json.Marshal((*propertyData)(d))
+	if err != nil {
+		return nil, err
+	}
+	if d.Type == PropertyTypeCheckbox && !d.Checkbox {
+		// The unchecked checkbox is omitted by omitempty.
+		b, err = encodeUnknownFields(b, nil, map[string]json.RawMessage{"checkbox": json.RawMessage("false")})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return encodeUnknownFields(b, reflect.TypeOf(propertyData{}), d.UnknownFields)
+}
+
+// RawValue returns the raw JSON of the value of the property.
+// RawValue is useful to read the property type which is not supported by this package.
+// If the value is not found, RawValue returns nil.
+func (d *PropertyData) RawValue() json.RawMessage {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil
+	}
+	m := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+	return m[string(d.Type)]
+}
+
+// isEmpty reports whether the property has no value.
+// The properties which are computed by Notion are always empty.
+func (d *PropertyData) isEmpty() bool {
+	switch d.Type {
+	case PropertyTypeTitle:
+		return len(d.Title) == 0
+	case PropertyTypeText:
+		return len(d.Text) == 0
+	case PropertyTypeRichText:
+		return len(d.RichText) == 0
+	case PropertyTypeNumber:
+		return d.Number == nil
+	case PropertyTypeSelect:
+		return d.Select == nil
+	case PropertyTypeStatus:
+		return d.Status == nil
+	case PropertyTypeMultiSelect:
+		return len(d.MultiSelect) == 0
+	case PropertyTypeDate:
+		return d.Date == nil
+	case PropertyTypePeople:
+		return len(d.People) == 0
+	case PropertyTypeFiles:
+		return len(d.Files) == 0
+	case PropertyTypeCheckbox:
+		return !d.Checkbox
+	case PropertyTypeURL:
+		return d.URL == ""
+	case PropertyTypeEmail:
+		return d.Email == ""
+	case PropertyTypePhoneNumber:
+		return d.PhoneNumber == ""
+	case PropertyTypeRelation:
+		return len(d.Relation) == 0
+	default:
+		return true
+	}
 }
 
 // TODO: Support formula, relation and rollup
@@ -496,17 +913,25 @@ func (d *PropertyData) String() string {
 		}
 		return b.String()
 	case PropertyTypeNumber:
-		return fmt.Sprintf("%d", d.Number)
+		if d.Number == nil {
+			return ""
+		}
+		return FormatNumber(*d.Number, NumberFormatNumber)
 	case PropertyTypeSelect:
 		if d.Select == nil {
 			return ""
 		}
 		return d.Select.Name
+	case PropertyTypeStatus:
+		if d.Status == nil {
+			return ""
+		}
+		return d.Status.Name
 	case PropertyTypeDate:
-		if d.Date.Start == nil {
+		if d.Date == nil {
 			return ""
 		}
-		return d.Date.Start.Format("2006-01-02")
+		return d.Date.String()
 	case PropertyTypePeople:
 		var b strings.Builder
 		for i, p := range d.People {
@@ -554,8 +979,110 @@ func (d *PropertyData) String() string {
 	return ""
 }
 
+type FileType string
+
+const (
+	FileTypeFile       FileType = "file"
+	FileTypeExternal   FileType = "external"
+	FileTypeFileUpload FileType = "file_upload"
+)
+
+// File is the file object of the files property.
+// ref: https://developers.notion.com/reference/file-object
 type File struct {
-	Name string `json:"name"`
+	Type FileType `json:"type,omitempty"`
+	Name string   `json:"name"`
+
+	External   *ExternalFile        `json:"external,omitempty"`
+	File       *NotionFile          `json:"file,omitempty"`
+	FileUpload *FileUploadReference `json:"file_upload,omitempty"`
+}
+
+// URL returns the URL of the file. The file which is uploaded by file upload API doesn't have the URL until it is retrieved.
+func (f *File) URL() string {
+	switch {
+	case f.External != nil:
+		return f.External.URL
+	case f.File != nil:
+		return f.File.URL
+	}
+	return ""
+}
+
+// Expired reports whether the URL of the file hosted by Notion is expired at t.
+func (f *File) Expired(t time.Time) bool {
+	return f.File.Expired(t)
+}
+
+type FileUploadReference struct {
+	ID string `json:"id"`
+}
+
+type FileUploadMode string
+
+const (
+	FileUploadModeSinglePart FileUploadMode = "single_part"
+	FileUploadModeMultiPart  FileUploadMode = "multi_part"
+)
+
+type FileUploadStatus string
+
+const (
+	FileUploadStatusPending  FileUploadStatus = "pending"
+	FileUploadStatusUploaded FileUploadStatus = "uploaded"
+	FileUploadStatusExpired  FileUploadStatus = "expired"
+	FileUploadStatusFailed   FileUploadStatus = "failed"
+)
+
+// FileUpload is a file upload object.
+// ref: https://developers.notion.com/reference/file-upload
+type FileUpload struct {
+	*Meta
+
+	CreatedTime    *Time            `json:"created_time,omitempty"`
+	LastEditedTime *Time            `json:"last_edited_time,omitempty"`
+	ExpiryTime     *Time            `json:"expiry_time,omitempty"`
+	Status         FileUploadStatus `json:"status"`
+	Filename       string           `json:"filename,omitempty"`
+	ContentType    string           `json:"content_type,omitempty"`
+	ContentLength  int64            `json:"content_length,omitempty"`
+	UploadURL      string           `json:"upload_url,omitempty"`
+	CompleteURL    string           `json:"complete_url,omitempty"`
+	NumberOfParts  *NumberOfParts   `json:"number_of_parts,omitempty"`
+}
+
+type NumberOfParts struct {
+	Total int `json:"total"`
+	Sent  int `json:"sent"`
+}
+
+// File returns the file object for the files property.
+func (f *FileUpload) File() *File {
+	return &File{Type: FileTypeFileUpload, Name: f.Filename, FileUpload: &FileUploadReference{ID: f.ID}}
+}
+
+// Block returns the block which has the uploaded file.
+// t should be one of BlockTypeImage, BlockTypeFile and BlockTypePDF.
+func (f *FileUpload) Block(t BlockType) (*Block, error) {
+	fb := &FileBlock{Type: FileTypeFileUpload, FileUpload: &FileUploadReference{ID: f.ID}}
+	b := &Block{Meta: &Meta{Object: ObjectTypeBlock}, Type: t}
+	switch t {
+	case BlockTypeImage:
+		b.Image = fb
+	case BlockTypeVideo:
+		b.Video = fb
+	case BlockTypeAudio:
+		b.Audio = fb
+	case BlockTypeFile:
+		fb.Name = f.Filename
+		b.File = fb
+	case BlockTypePDF:
+		b.PDF = fb
+	default:
+		return nil, fmt.Errorf("notion: %s block can't have a file", t)
+	}
+
+	return b, nil
 }
 
 type UniqueID struct {
@@ -570,6 +1097,7 @@ type Filter struct {
 
 	// Database property filter
 	Property    string             `json:"property,omitempty"`
+	Title       *RichTextFilter    `json:"title,omitempty"`
 	RichText    *RichTextFilter    `json:"rich_text,omitempty"`
 	Number      *NumberFilter      `json:"number,omitempty"`
 	Checkbox    *CheckboxFilter    `json:"checkbox,omitempty"`
@@ -583,6 +1111,7 @@ type Filter struct {
 	PhoneNumber *PhoneNumberFilter `json:"phone_number,omitempty"`
 	Status      *StatusFilter      `json:"status,omitempty"`
 	Timestamp   *TimestampFilter   `json:"timestamp,omitempty"`
+	UniqueID    *UniqueIDFilter    `json:"unique_id,omitempty"`
 }
 
 type RichTextFilter struct {
@@ -596,15 +1125,17 @@ type RichTextFilter struct {
 	StartsWith     string `json:"starts_with,omitempty"`
 }
 
+// NumberFilter is the filter of the number property.
+// The conditions are pointers so that the condition with zero (e.g. equals 0) can be specified.
 type NumberFilter struct {
-	DoesNotEqual         int  `json:"does_not_equal,omitempty"`
-	Equals               int  `json:"equals,omitempty"`
-	GreaterThan          int  `json:"greater_than,omitempty"`
-	GreaterThanOrEqualTo int  `json:"greater_than_or_equal_to,omitempty"`
-	IsEmpty              bool `json:"is_empty,omitempty"`
-	IsNotEmpty           bool `json:"is_not_empty,omitempty"`
-	LessThan             int  `json:"less_than,omitempty"`
-	LessThanOrEqualTo    int  `json:"less_than_or_equal_to,omitempty"`
+	DoesNotEqual         *float64 `json:"does_not_equal,omitempty"`
+	Equals               *float64 `json:"equals,omitempty"`
+	GreaterThan          *float64 `json:"greater_than,omitempty"`
+	GreaterThanOrEqualTo *float64 `json:"greater_than_or_equal_to,omitempty"`
+	IsEmpty              bool     `json:"is_empty,omitempty"`
+	IsNotEmpty           bool     `json:"is_not_empty,omitempty"`
+	LessThan             *float64 `json:"less_than,omitempty"`
+	LessThanOrEqualTo    *float64 `json:"less_than_or_equal_to,omitempty"`
 }
 
 type CheckboxFilter struct {
@@ -627,16 +1158,16 @@ type MultiSelectFilter struct {
 }
 
 type DateFilter struct {
-	After      *Time     `json:"after,omitempty"`
-	Before     *Time     `json:"before,omitempty"`
-	Equals     *Time     `json:"equals,omitempty"`
+	After      *Date     `json:"after,omitempty"`
+	Before     *Date     `json:"before,omitempty"`
+	Equals     *Date     `json:"equals,omitempty"`
 	IsEmpty    bool      `json:"is_empty,omitempty"`
 	IsNotEmpty bool      `json:"is_not_empty,omitempty"`
 	NextMonth  *struct{} `json:"next_month,omitempty"`
 	NextWeek   *struct{} `json:"next_week,omitempty"`
 	NextYear   *struct{} `json:"next_year,omitempty"`
-	OnOrAfter  *Time     `json:"on_or_after,omitempty"`
-	OnOrBefore *Time     `json:"on_or_before,omitempty"`
+	OnOrAfter  *Date     `json:"on_or_after,omitempty"`
+	OnOrBefore *Date     `json:"on_or_before,omitempty"`
 	PastMonth  *struct{} `json:"past_month,omitempty"`
 	PastWeek   *struct{} `json:"past_week,omitempty"`
 	PastYear   *struct{} `json:"past_year,omitempty"`
@@ -680,6 +1211,15 @@ type StatusFilter struct {
 	IsNotEmpty   bool   `json:"is_not_empty,omitempty"`
 }
 
+type UniqueIDFilter struct {
+	DoesNotEqual         int `json:"does_not_equal,omitempty"`
+	Equals               int `json:"equals,omitempty"`
+	GreaterThan          int `json:"greater_than,omitempty"`
+	GreaterThanOrEqualTo int `json:"greater_than_or_equal_to,omitempty"`
+	LessThan             int `json:"less_than,omitempty"`
+	LessThanOrEqualTo    int `json:"less_than_or_equal_to,omitempty"`
+}
+
 type TimestampType string
 
 const (
@@ -693,9 +1233,50 @@ type TimestampFilter struct {
 	LastEditedTime *DateFilter   `json:"last_edited_time,omitempty"`
 }
 
+// MarshalJSON flattens the timestamp filter.
+// The timestamp filter is not nested in the filter object unlike the property filter.
+// ref: https://developers.notion.com/reference/post-database-query-filter#timestamp
+func (f *Filter) MarshalJSON() ([]byte, error) {
+	type filter Filter
+	if f.Timestamp == nil {
+		return json.Marshal((*filter)(f))
+	}
+
+	return json.Marshal(struct {
+		*filter
+		Timestamp      TimestampType `json:"timestamp"`
+		CreatedTime    *DateFilter   `json:"created_time,omitempty"`
+		LastEditedTime *DateFilter   `json:"last_edited_time,omitempty"`
+	}{
+		filter:         (*filter)(f),
+		Timestamp:      f.Timestamp.Timestamp,
+		CreatedTime:    f.Timestamp.CreatedTime,
+		LastEditedTime: f.Timestamp.LastEditedTime,
+	})
+}
+
+func (f *Filter) UnmarshalJSON(b []byte) error {
+	type filter Filter
+	v := struct {
+		*filter
+		Timestamp      TimestampType `json:"timestamp"`
+		CreatedTime    *DateFilter   `json:"created_time,omitempty"`
+		LastEditedTime *DateFilter   `json:"last_edited_time,omitempty"`
+	}{filter: (*filter)(f)}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v.Timestamp != "" {
+		f.Timestamp = &TimestampFilter{Timestamp: v.Timestamp, CreatedTime: v.CreatedTime, LastEditedTime: v.LastEditedTime}
+	}
+
+	return nil
+}
+
+// Sort is the sort of the query. Either Property or Timestamp is specified.
 type Sort struct {
-	Property  string `json:"property"`
-	Timestamp string `json:"timestamp"`
+	Property  string `json:"property,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
 	Direction string `json:"direction"`
 }
 
@@ -718,6 +1299,7 @@ const (
 	BlockTypeEmbed            BlockType = "embed"
 	BlockTypeImage            BlockType = "image"
 	BlockTypeVideo            BlockType = "video"
+	BlockTypeAudio            BlockType = "audio"
 	BlockTypeFile             BlockType = "file"
 	BlockTypePDF              BlockType = "pdf"
 	BlockTypeBookmark         BlockType = "bookmark"
@@ -730,6 +1312,8 @@ const (
 	BlockTypeLinkPreview      BlockType = "link_preview"
 	BlockTypeLinkToPage       BlockType = "link_to_page"
 	BlockTypeSynced           BlockType = "synced_block"
+	BlockTypeTable            BlockType = "table"
+	BlockTypeTableRow         BlockType = "table_row"
 )
 
 // Block is a block object.
@@ -743,20 +1327,165 @@ type Block struct {
 	Archived       bool      `json:"archived"`
 	Type           BlockType `json:"type"`
 
-	Paragraph        *Paragraph `json:"paragraph,omitempty"`
-	Heading1         *Heading   `json:"heading_1,omitempty"`
-	Heading2         *Heading   `json:"heading_2,omitempty"`
-	Heading3         *Heading   `json:"heading_3,omitempty"`
-	BulletedListItem *Paragraph `json:"bulleted_list_item,omitempty"`
-	NumberedListItem *Paragraph `json:"numbered_list_item,omitempty"`
-	ToDo             *ToDo      `json:"to_do,omitempty"`
-	Toggle           *Paragraph `json:"toggle,omitempty"`
-	ChildPage        *ChildPage `json:"child_page,omitempty"`
-	Divider          *struct{}  `json:"divider,omitempty"`
-	Code             *Code      `json:"code,omitempty"`
-	ColumnList       *struct{}  `json:"column_list,omitempty"`
-	Breadcrumb       *struct{}  `json:"breadcrumb,omitempty"`
-	TableOfContents  *struct{}  `json:"table_of_contents,omitempty"`
+	Paragraph        *Paragraph     `json:"paragraph,omitempty"`
+	Heading1         *Heading       `json:"heading_1,omitempty"`
+	Heading2         *Heading       `json:"heading_2,omitempty"`
+	Heading3         *Heading       `json:"heading_3,omitempty"`
+	CallOut          *CallOut       `json:"callout,omitempty"`
+	Quote            *Paragraph     `json:"quote,omitempty"`
+	BulletedListItem *Paragraph     `json:"bulleted_list_item,omitempty"`
+	NumberedListItem *Paragraph     `json:"numbered_list_item,omitempty"`
+	ToDo             *ToDo          `json:"to_do,omitempty"`
+	Toggle           *Paragraph     `json:"toggle,omitempty"`
+	ChildPage        *ChildPage     `json:"child_page,omitempty"`
+	ChildDatabase    *ChildDatabase `json:"child_database,omitempty"`
+	Divider          *struct{}      `json:"divider,omitempty"`
+	Code             *Code          `json:"code,omitempty"`
+	Embed            *Embed         `json:"embed,omitempty"`
+	Bookmark         *Bookmark      `json:"bookmark,omitempty"`
+	Equation         *Equation      `json:"equation,omitempty"`
+	ColumnList       *ColumnList    `json:"column_list,omitempty"`
+	Column           *Column        `json:"column,omitempty"`
+	Breadcrumb       *struct{}      `json:"breadcrumb,omitempty"`
+	TableOfContents  *struct{}      `json:"table_of_contents,omitempty"`
+	LinkPreview      *LinkPreview   `json:"link_preview,omitempty"`
+	LinkToPage       *LinkToPage    `json:"link_to_page,omitempty"`
+	Synced           *SyncedBlock   `json:"synced_block,omitempty"`
+	Table            *Table         `json:"table,omitempty"`
+	TableRow         *TableRow      `json:"table_row,omitempty"`
+	Image            *FileBlock     `json:"image,omitempty"`
+	Video            *FileBlock     `json:"video,omitempty"`
+	Audio            *FileBlock     `json:"audio,omitempty"`
+	File             *FileBlock     `json:"file,omitempty"`
+	PDF              *FileBlock     `json:"pdf,omitempty"`
+
+	// UnknownFields has the fields which are not supported by this package (e.g. the content of the new block type).
+	// The unknown fields in the content of the known type are kept as the object under the name of the type.
+	// The fields which are owned by the API (e.g. parent, in_trash) are not kept.
+	// UnknownFields is encoded with the block as is. Thus the block can be updated without losing the content.
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// blockReadOnlyFields are the fields of the block which are owned by the API and can't be sent back.
+var blockReadOnlyFields = map[string]struct{}{
+	"parent":         {},
+	"created_by":     {},
+	"last_edited_by": {},
+	"in_trash":       {},
+}
+
+func (b *Block) UnmarshalJSON(data []byte) error {
+	type block Block
+	if err := json.Unmarshal(data, (*block)(b)); err != nil {
+		return err
+	}
+	unknown, err := decodeUnknownFields(data, reflect.TypeOf(block{}), blockReadOnlyFields)
+	if err != nil {
+		return err
+	}
+	b.UnknownFields = unknown
+
+	return nil
+}
+
+func (b *Block) MarshalJSON() ([]byte, error) {
+	type block Block
+	data, err := json.Marshal((*block)(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeUnknownFields(data, reflect.TypeOf(block{}), b.UnknownFields)
+}
+
+// FileBlock returns the content of the file-bearing block (image, video, audio, file and pdf).
+// If the block is not the file-bearing block, FileBlock returns nil.
+func (b *Block) FileBlock() *FileBlock {
+	switch b.Type {
+	case BlockTypeImage:
+		return b.Image
+	case BlockTypeVideo:
+		return b.Video
+	case BlockTypeAudio:
+		return b.Audio
+	case BlockTypeFile:
+		return b.File
+	case BlockTypePDF:
+		return b.PDF
+	}
+	return nil
+}
+
+// HasExpiredFile reports whether the block has the file which URL is expired at t.
+func (b *Block) HasExpiredFile(t time.Time) bool {
+	if f := b.FileBlock(); f != nil && f.Expired(t) {
+		return true
+	}
+	if b.CallOut != nil && b.CallOut.Icon != nil && b.CallOut.Icon.File.Expired(t) {
+		return true
+	}
+	return false
+}
+
+// RawContent returns the raw JSON of the content of the block.
+// RawContent is useful to read the block type which is not supported by this package.
+// If the content is not found, RawContent returns nil.
+func (b *Block) RawContent() json.RawMessage {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return nil
+	}
+	m := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	return m[string(b.Type)]
+}
+
+// copy returns the new block which has the same content as b.
+// The children of b are not copied.
+func (b *Block) copy() *Block {
+	n := *b
+	n.Meta = nil
+	n.CreatedTime = Time{}
+	n.LastEditedTime = Time{}
+	n.HasChildren = false
+	n.Archived = false
+	return &n
+}
+
+// copyable reports whether the copy of the block can be created by the API.
+func (b *Block) copyable() bool {
+	switch b.Type {
+	case BlockTypeChildPage, BlockTypeChildDatabase:
+		return false
+	}
+	// The URL of the file which is hosted by Notion expires, so the file can't be created from the URL.
+	if f := b.FileBlock(); f != nil && f.Type == FileTypeFile {
+		return false
+	}
+	return true
+}
+
+// setChildren sets the children which are created with the block.
+func (b *Block) setChildren(children []*Block) {
+	switch b.Type {
+	case BlockTypeTable:
+		if b.Table != nil {
+			t := *b.Table
+			t.Children = children
+			b.Table = &t
+		}
+	case BlockTypeColumnList:
+		b.ColumnList = &ColumnList{Children: children}
+	case BlockTypeColumn:
+		col := &Column{}
+		if b.Column != nil {
+			*col = *b.Column
+		}
+		col.Children = children
+		b.Column = col
+	}
 }
 
 type BlockList struct {
@@ -773,23 +1502,172 @@ type Paragraph struct {
 type Heading struct {
 	RichText []*RichTextObject `json:"rich_text"`
 	Color    string            `json:"color,omitempty"`
+	// IsToggleable is true if the heading is the toggle heading. Only the toggle heading can have children.
+	IsToggleable bool     `json:"is_toggleable,omitempty"`
+	Children     []*Block `json:"children,omitempty"`
 }
 
 type ToDo struct {
-	Text     []*RichTextObject `json:"text"`
+	RichText []*RichTextObject `json:"rich_text,omitempty"`
 	Checked  bool              `json:"checked"`
-	Children []*RichTextObject `json:"children"`
+	Color    string            `json:"color,omitempty"`
+	// Deprecated: Use RichText. The field has been renamed to rich_text by the API.
+	Text []*RichTextObject `json:"text,omitempty"`
+	// Deprecated: Children of to_do is not the rich text.
+	Children []*RichTextObject `json:"children,omitempty"`
+}
+
+type CallOut struct {
+	RichText []*RichTextObject `json:"rich_text"`
+	Icon     *Icon             `json:"icon,omitempty"`
+	Color    string            `json:"color,omitempty"`
+	Children []*Block          `json:"children,omitempty"`
+}
+
+// FileBlock is the content of image, video, audio, file and pdf block.
+type FileBlock struct {
+	Type    FileType          `json:"type,omitempty"`
+	Caption []*RichTextObject `json:"caption,omitempty"`
+	Name    string            `json:"name,omitempty"`
+
+	External   *ExternalFile        `json:"external,omitempty"`
+	File       *NotionFile          `json:"file,omitempty"`
+	FileUpload *FileUploadReference `json:"file_upload,omitempty"`
+}
+
+// URL returns the URL of the file. The file which is uploaded by file upload API doesn't have the URL until it is retrieved.
+func (f *FileBlock) URL() string {
+	switch {
+	case f.External != nil:
+		return f.External.URL
+	case f.File != nil:
+		return f.File.URL
+	}
+	return ""
+}
+
+// Expired reports whether the URL of the file hosted by Notion is expired at t.
+func (f *FileBlock) Expired(t time.Time) bool {
+	return f.File.Expired(t)
+}
+
+// ExternalFile is the file which is hosted outside of Notion.
+type ExternalFile struct {
+	URL string `json:"url"`
+}
+
+// NotionFile is the file which is hosted by Notion.
+// URL is the temporary URL which is expired at ExpiryTime.
+type NotionFile struct {
+	URL        string `json:"url"`
+	ExpiryTime *Time  `json:"expiry_time,omitempty"`
+}
+
+// Expired reports whether URL is expired at t.
+// The file which doesn't have ExpiryTime is never expired.
+func (f *NotionFile) Expired(t time.Time) bool {
+	if f == nil || f.ExpiryTime == nil {
+		return false
+	}
+	return !t.Before(f.ExpiryTime.Time)
+}
+
+type IconType string
+
+const (
+	IconTypeEmoji       IconType = "emoji"
+	IconTypeExternal    IconType = "external"
+	IconTypeFile        IconType = "file"
+	IconTypeCustomEmoji IconType = "custom_emoji"
+)
+
+type Icon struct {
+	Type        IconType      `json:"type"`
+	Emoji       string        `json:"emoji,omitempty"`
+	External    *ExternalFile `json:"external,omitempty"`
+	File        *NotionFile   `json:"file,omitempty"`
+	CustomEmoji *CustomEmoji  `json:"custom_emoji,omitempty"`
+}
+
+type CustomEmoji struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
 }
 
 type ChildPage struct {
 	Title string `json:"title"`
 }
 
+type ChildDatabase struct {
+	Title string `json:"title"`
+}
+
 type Code struct {
 	RichText []*RichTextObject `json:"rich_text"`
+	Caption  []*RichTextObject `json:"caption,omitempty"`
 	Language string            `json:"language"`
 }
 
+type Embed struct {
+	URL     string            `json:"url"`
+	Caption []*RichTextObject `json:"caption,omitempty"`
+}
+
+type Bookmark struct {
+	URL     string            `json:"url"`
+	Caption []*RichTextObject `json:"caption,omitempty"`
+}
+
+// ColumnList is the content of column_list block. The columns have to be created with the column list.
+type ColumnList struct {
+	Children []*Block `json:"children,omitempty"`
+}
+
+type Column struct {
+	// WidthRatio is the ratio of the width of the column. The sum of the ratio of the columns in a column list is 1.
+	WidthRatio float64  `json:"width_ratio,omitempty"`
+	Children   []*Block `json:"children,omitempty"`
+}
+
+// LinkPreview is the link preview block. The block can't be created by the API.
+type LinkPreview struct {
+	URL string `json:"url"`
+}
+
+type LinkToPage struct {
+	Type       ObjectType `json:"type"`
+	PageID     string     `json:"page_id,omitempty"`
+	DatabaseID string     `json:"database_id,omitempty"`
+}
+
+// SyncedBlock is the content of synced_block.
+// The original synced block has no SyncedFrom. The duplicate synced block refers to the original block by SyncedFrom.
+type SyncedBlock struct {
+	SyncedFrom *SyncedFrom `json:"synced_from"`
+	Children   []*Block    `json:"children,omitempty"`
+}
+
+type SyncedFrom struct {
+	Type    string `json:"type"`
+	BlockID string `json:"block_id"`
+}
+
+// Table is the content of the table block. The rows of the table are the children of the block.
+// TableWidth can't be changed after the table is created.
+type Table struct {
+	TableWidth      int  `json:"table_width"`
+	HasColumnHeader bool `json:"has_column_header"`
+	HasRowHeader    bool `json:"has_row_header"`
+	// Children is the rows of the table. Children is required when the table is created.
+	Children []*Block `json:"children,omitempty"`
+}
+
+// TableRow is the content of the table_row block. The length of Cells is the same as TableWidth of the table.
+type TableRow struct {
+	Cells [][]*RichTextObject `json:"cells"`
+}
+
 type SearchResult struct {
 	*ListMeta
 	Results []*json.RawMessage `json:"results"`
@@ -807,3 +1685,22 @@ var _ error = &Error{}
 func (e *Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
+
+// PaginationError is returned when the pagination is interrupted.
+// The results which are collected before the error are returned with PaginationError.
+type PaginationError struct {
+	// NextCursor is the cursor to resume the pagination.
+	// Empty string means the pagination has to start from the beginning.
+	NextCursor string
+	Err        error
+}
+
+var _ error = &PaginationError{}
+
+func (e *PaginationError) Error() string {
+	return fmt.Sprintf("notion: pagination is interrupted: %v", e.Err)
+}
+
+func (e *PaginationError) Unwrap() error {
+	return e.Err
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTime(t *testing.T) {
@@ -43,6 +44,271 @@ func TestTime(t *testing.T) {
 	})
 }
 
+func TestFilter_Timestamp(t *testing.T) {
+	f := &Filter{
+		And: []*Filter{
+			{
+				Timestamp: &TimestampFilter{
+					Timestamp:      TimestampTypeLastEditedTime,
+					LastEditedTime: &DateFilter{OnOrAfter: NewDateTime(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))},
+				},
+			},
+			{Property: "Done", Checkbox: &CheckboxFilter{Equals: true}},
+		},
+	}
+
+	b, err := json.Marshal(f)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"and":[{"timestamp":"last_edited_time","last_edited_time":{"on_or_after":"2024-03-01T10:00:00.000Z"}},{"property":"Done","checkbox":{"equals":true}}]}`, string(b))
+
+	decoded := &Filter{}
+	require.NoError(t, json.Unmarshal(b, decoded))
+	require.Len(t, decoded.And, 2)
+	if assert.NotNil(t, decoded.And[0].Timestamp) {
+		assert.Equal(t, TimestampTypeLastEditedTime, decoded.And[0].Timestamp.Timestamp)
+		assert.True(t, f.And[0].Timestamp.LastEditedTime.OnOrAfter.Equal(decoded.And[0].Timestamp.LastEditedTime.OnOrAfter.Time))
+	}
+	assert.Nil(t, decoded.And[1].Timestamp)
+}
+
+func TestSort(t *testing.T) {
+	b, err := json.Marshal([]*Sort{
+		{Timestamp: string(TimestampTypeLastEditedTime), Direction: "ascending"},
+		{Property: "Name", Direction: "descending"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"timestamp":"last_edited_time","direction":"ascending"},{"property":"Name","direction":"descending"}]`, string(b))
+}
+
+func TestDateProperty(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DateOnly", func(t *testing.T) {
+		t.Parallel()
+
+		d := &DateProperty{}
+		require.NoError(t, json.Unmarshal([]byte(`{"start":"2024-03-01","end":null,"time_zone":null}`), d))
+		assert.False(t, d.Start.HasTime)
+		assert.False(t, d.IsRange())
+		assert.Equal(t, "2024-03-01", d.String())
+
+		b, err := json.Marshal(d)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"start":"2024-03-01"}`, string(b))
+	})
+
+	t.Run("DateTime", func(t *testing.T) {
+		t.Parallel()
+
+		d := &DateProperty{}
+		require.NoError(t, json.Unmarshal([]byte(`{"start":"2024-03-01T10:00:00.000+09:00","end":"2024-03-01T12:30:00.000+09:00"}`), d))
+		assert.True(t, d.Start.HasTime)
+		assert.True(t, d.IsRange())
+		assert.True(t, time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC).Equal(d.Start.Time))
+		assert.True(t, time.Date(2024, 3, 1, 3, 30, 0, 0, time.UTC).Equal(d.End.Time))
+
+		b, err := json.Marshal(d)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"start":"2024-03-01T10:00:00.000+09:00","end":"2024-03-01T12:30:00.000+09:00"}`, string(b))
+	})
+
+	t.Run("TimeZone", func(t *testing.T) {
+		t.Parallel()
+
+		d := &DateProperty{}
+		require.NoError(t, json.Unmarshal([]byte(`{"start":"2024-03-01T10:00:00.000","time_zone":"America/New_York"}`), d))
+		assert.Equal(t, "America/New_York", d.TimeZone)
+		assert.True(t, time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC).Equal(d.Start.Time))
+
+		b, err := json.Marshal(d)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"start":"2024-03-01T10:00:00.000","time_zone":"America/New_York"}`, string(b))
+
+		d = &DateProperty{Start: NewDateTime(time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC)), TimeZone: "Asia/Tokyo"}
+		b, err = json.Marshal(d)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"start":"2024-03-01T10:00:00.000","time_zone":"Asia/Tokyo"}`, string(b))
+	})
+
+	t.Run("UnknownTimeZone", func(t *testing.T) {
+		t.Parallel()
+
+		d := &DateProperty{}
+		require.NoError(t, json.Unmarshal([]byte(`{"start":"2024-03-01T10:00:00.000","time_zone":"Mars/Olympus_Mons"}`), d))
+		assert.Equal(t, "Mars/Olympus_Mons", d.TimeZone)
+		assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), d.Start.Time)
+
+		b, err := json.Marshal(d)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"start":"2024-03-01T10:00:00.000","time_zone":"Mars/Olympus_Mons"}`, string(b))
+
+		d = &DateProperty{Start: NewDateTime(time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC)), TimeZone: "Mars/Olympus_Mons"}
+		_, err = json.Marshal(d)
+		require.Error(t, err)
+	})
+
+	t.Run("Page", func(t *testing.T) {
+		t.Parallel()
+
+		page := &Page{}
+		err := json.Unmarshal([]byte(`{"object":"page","id":"p","properties":{"Due":{"id":"a","type":"date","date":{"start":"2024-03-01T10:00:00.000+09:00","end":null,"time_zone":null}}}}`), page)
+		require.NoError(t, err)
+		assert.Equal(t, "2024-03-01T10:00:00.000+09:00", page.Properties["Due"].String())
+	})
+}
+
+func TestStatusProperty(t *testing.T) {
+	t.Parallel()
+
+	db := &Database{}
+	err := json.Unmarshal([]byte(`{
+		"object": "database",
+		"id": "d",
+		"properties": {
+			"Status": {
+				"id": "s%3D",
+				"name": "Status",
+				"type": "status",
+				"status": {
+					"options": [
+						{"id": "o1", "name": "Not started", "color": "default"},
+						{"id": "o2", "name": "In progress", "color": "blue"},
+						{"id": "o3", "name": "Done", "color": "green"}
+					],
+					"groups": [
+						{"id": "g1", "name": "To-do", "color": "gray", "option_ids": ["o1"]},
+						{"id": "g2", "name": "In progress", "color": "blue", "option_ids": ["o2"]},
+						{"id": "g3", "name": "Complete", "color": "green", "option_ids": ["o3"]}
+					]
+				}
+			}
+		}
+	}`), db)
+	require.NoError(t, err)
+	status := db.Properties["Status"].Status
+	require.NotNil(t, status)
+	assert.Len(t, status.Options, 3)
+	assert.Len(t, status.Groups, 3)
+	if g := status.Group("Done"); assert.NotNil(t, g) {
+		assert.Equal(t, "Complete", g.Name)
+	}
+	assert.Nil(t, status.Group("Unknown"))
+
+	page := &Page{}
+	err = json.Unmarshal([]byte(`{"object":"page","id":"p","properties":{"Status":{"id":"s%3D","type":"status","status":{"id":"o2","name":"In progress","color":"blue"}}}}`), page)
+	require.NoError(t, err)
+	if assert.NotNil(t, page.Properties["Status"].Status) {
+		assert.Equal(t, "o2", page.Properties["Status"].Status.ID)
+		assert.Equal(t, "blue", page.Properties["Status"].Status.Color)
+	}
+
+	b, err := json.Marshal(&PropertyData{Type: PropertyTypeStatus, Status: &Option{Name: "Done"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"status","status":{"name":"Done"}}`, string(b))
+	b, err = json.Marshal(&PropertyMetadata{Status: &StatusProperty{}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":{}}`, string(b))
+}
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	files := []*File{}
+	err := json.Unmarshal([]byte(`[
+		{"name": "spec.pdf", "type": "file", "file": {"url": "https://prod-files-secure.s3.us-west-2.amazonaws.com/spec.pdf", "expiry_time": "2024-03-01T11:00:00.000Z"}},
+		{"name": "logo", "type": "external", "external": {"url": "https://example.com/logo.png"}}
+	]`), &files)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, FileTypeFile, files[0].Type)
+	assert.Equal(t, "https://prod-files-secure.s3.us-west-2.amazonaws.com/spec.pdf", files[0].URL())
+	assert.False(t, files[0].Expired(time.Date(2024, 3, 1, 10, 59, 0, 0, time.UTC)))
+	assert.True(t, files[0].Expired(time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, FileTypeExternal, files[1].Type)
+	assert.Equal(t, "https://example.com/logo.png", files[1].URL())
+	assert.False(t, files[1].Expired(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestBlock_UnknownFields(t *testing.T) {
+	t.Parallel()
+
+	data := `{"object":"block","id":"6fbac55c-9e74-4489-b386-0c88d5aa54dd","parent":{"type":"page_id","page_id":"p"},"type":"ai_summary","ai_summary":{"rich_text":[],"model":"x"},"in_trash":false,"has_children":false,"archived":false}`
+	block := &Block{}
+	require.NoError(t, json.Unmarshal([]byte(data), block))
+	assert.Equal(t, BlockType("ai_summary"), block.Type)
+	assert.JSONEq(t, `{"rich_text":[],"model":"x"}`, string(block.RawContent()))
+	// The fields which are owned by the API are not kept.
+	assert.Equal(t, []string{"ai_summary"}, keys(block.UnknownFields))
+
+	b, err := json.Marshal(block)
+	require.NoError(t, err)
+	m := make(map[string]json.RawMessage)
+	require.NoError(t, json.Unmarshal(b, &m))
+	assert.JSONEq(t, `{"rich_text":[],"model":"x"}`, string(m["ai_summary"]))
+	assert.NotContains(t, m, "parent")
+	assert.NotContains(t, m, "in_trash")
+
+	// The known fields take precedence over the unknown fields.
+	block.UnknownFields["archived"] = json.RawMessage(`true`)
+	b, err = json.Marshal(block)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"archived":false`)
+
+	// The unknown fields of the known block type are kept at every level.
+	block = &Block{}
+	data = `{"object":"block","id":"b","parent":{"type":"page_id","page_id":"p"},"type":"paragraph","paragraph":{"rich_text":[],"color":"default","text_direction":"rtl"},"in_trash":false,"has_children":false,"archived":false,"request_id":"r"}`
+	require.NoError(t, json.Unmarshal([]byte(data), block))
+	assert.ElementsMatch(t, []string{"paragraph", "request_id"}, keys(block.UnknownFields))
+	assert.JSONEq(t, `{"color":"default","text_direction":"rtl"}`, string(block.RawContent()))
+	b, err = json.Marshal(block)
+	require.NoError(t, err)
+	m = make(map[string]json.RawMessage)
+	require.NoError(t, json.Unmarshal(b, &m))
+	assert.JSONEq(t, `{"color":"default","text_direction":"rtl"}`, string(m["paragraph"]))
+	assert.JSONEq(t, `"r"`, string(m["request_id"]))
+	assert.NotContains(t, m, "parent")
+	assert.NotContains(t, m, "in_trash")
+
+	// The unknown fields of the cleared content are not encoded.
+	block.Type, block.Paragraph, block.Quote = BlockTypeQuote, nil, &Paragraph{RichText: []*RichTextObject{}}
+	b, err = json.Marshal(block)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "text_direction")
+
+	block = &Block{}
+	require.NoError(t, json.Unmarshal([]byte(`{"object":"block","id":"b","parent":{"type":"page_id","page_id":"p"},"type":"equation","equation":{"expression":"x^2"},"in_trash":false}`), block))
+	assert.Nil(t, block.UnknownFields)
+	assert.JSONEq(t, `{"expression":"x^2"}`, string(block.RawContent()))
+}
+
+func keys(m map[string]json.RawMessage) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestPropertyData_UnknownFields(t *testing.T) {
+	t.Parallel()
+
+	data := `{"id":"abc","type":"verification","verification":{"state":"verified"}}`
+	prop := &PropertyData{}
+	require.NoError(t, json.Unmarshal([]byte(data), prop))
+	assert.Equal(t, PropertyType("verification"), prop.Type)
+	assert.JSONEq(t, `{"state":"verified"}`, string(prop.RawValue()))
+
+	b, err := json.Marshal(prop)
+	require.NoError(t, err)
+	assert.JSONEq(t, data, string(b))
+
+	page := &Page{}
+	require.NoError(t, json.Unmarshal([]byte(`{"object":"page","id":"p","properties":{"Verification":`+data+`}}`), page))
+	assert.JSONEq(t, `{"state":"verified"}`, string(page.Properties["Verification"].RawValue()))
+}
+
 func TestPropertyData_String(t *testing.T) {
 	cases := []struct {
 		In     *PropertyData
@@ -90,7 +356,7 @@ func TestPropertyData_String(t *testing.T) {
 		{
 			In: &PropertyData{
 				Type:   "number",
-				Number: intPtr(10),
+				Number: ptr(10.0),
 			},
 			Expect: "10",
 		},
@@ -101,6 +367,13 @@ func TestPropertyData_String(t *testing.T) {
 			},
 			Expect: "foo",
 		},
+		{
+			In: &PropertyData{
+				Type:   "status",
+				Status: &Option{Name: "In progress"},
+			},
+			Expect: "In progress",
+		},
 		{
 			In: &PropertyData{
 				Type: "date",
@@ -214,10 +487,6 @@ func TestPropertyData_String(t *testing.T) {
 	}
 }
 
-func intPtr(v int) *int {
-	return &v
-}
-
 func MustParse(t time.Time, err error) time.Time {
 	if err != nil {
 		panic(err)
@@ -0,0 +1,356 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.f110.dev/notion-api/v3"
+)
+
+type BlockChangeType string
+
+const (
+	BlockChangeTypeInserted BlockChangeType = "inserted"
+	BlockChangeTypeDeleted  BlockChangeType = "deleted"
+	BlockChangeTypeModified BlockChangeType = "modified"
+	BlockChangeTypeMoved    BlockChangeType = "moved"
+)
+
+// BlockChange is the change of the block between two snapshots.
+// The moved block which is also modified is reported as two changes.
+type BlockChange struct {
+	Type    BlockChangeType
+	BlockID string
+	// Before is nil if Type is BlockChangeTypeInserted.
+	Before *BlockState
+	// After is nil if Type is BlockChangeTypeDeleted.
+	After *BlockState
+}
+
+// Snapshot is the block tree of the page.
+type Snapshot struct {
+	PageID  string                 `json:"page_id"`
+	TakenAt time.Time              `json:"taken_at"`
+	Blocks  map[string]*BlockState `json:"blocks"`
+	// Children is the ordered IDs of the children for each block. The key of the top-level blocks is PageID.
+	Children map[string][]string `json:"children"`
+}
+
+// BlockState is the content of the block in the snapshot.
+type BlockState struct {
+	ID             string           `json:"id"`
+	ParentID       string           `json:"parent_id"`
+	Type           notion.BlockType `json:"type"`
+	LastEditedTime time.Time        `json:"last_edited_time"`
+	// Content is the JSON of the block without the metadata (e.g. id, created_time).
+	Content json.RawMessage `json:"content"`
+	// Text is the plain text of the rich text of the block.
+	Text string `json:"text"`
+}
+
+// SnapshotStore persists the snapshots of the pages.
+type SnapshotStore interface {
+	// LoadSnapshot returns the saved snapshot. If there is no snapshot, LoadSnapshot returns nil.
+	LoadSnapshot(ctx context.Context, pageID string) (*Snapshot, error)
+	SaveSnapshot(ctx context.Context, s *Snapshot) error
+}
+
+// ContentWatcher detects the changes of the content of pages.
+type ContentWatcher struct {
+	client *notion.Client
+	store  SnapshotStore
+}
+
+// NewContentWatcher returns the new ContentWatcher.
+// If store is nil, the snapshots are kept in memory.
+func NewContentWatcher(client *notion.Client, store SnapshotStore) *ContentWatcher {
+	if store == nil {
+		store = NewMemorySnapshotStore()
+	}
+	return &ContentWatcher{client: client, store: store}
+}
+
+// Poll takes the snapshot of the page and returns the changes from the previous snapshot.
+// The first poll of the page returns no changes.
+func (w *ContentWatcher) Poll(ctx context.Context, pageID string) ([]*BlockChange, error) {
+	prev, err := w.store.LoadSnapshot(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := TakeSnapshot(ctx, w.client, pageID)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.store.SaveSnapshot(ctx, s); err != nil {
+		return nil, err
+	}
+	if prev == nil {
+		return nil, nil
+	}
+
+	return DiffSnapshots(prev, s), nil
+}
+
+// TakeSnapshot retrieves the block tree of the page.
+// The content of child pages and child databases are not included.
+func TakeSnapshot(ctx context.Context, client *notion.Client, pageID string) (*Snapshot, error) {
+	s := &Snapshot{PageID: pageID, TakenAt: time.Now(), Blocks: make(map[string]*BlockState), Children: make(map[string][]string)}
+	if err := s.walk(ctx, client, pageID); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Snapshot) walk(ctx context.Context, client *notion.Client, parentID string) error {
+	blocks, err := client.GetBlocks(ctx, parentID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range blocks {
+		state, err := newBlockState(parentID, v)
+		if err != nil {
+			return err
+		}
+		s.Blocks[v.ID] = state
+		s.Children[parentID] = append(s.Children[parentID], v.ID)
+
+		switch v.Type {
+		case notion.BlockTypeChildPage, notion.BlockTypeChildDatabase:
+			continue
+		}
+		if v.HasChildren {
+			if err := s.walk(ctx, client, v.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// metadataFields are the fields of the block which are not the content.
+var metadataFields = []string{"object", "id", "parent", "created_time", "created_by", "last_edited_time", "last_edited_by", "has_children", "archived", "in_trash"}
+
+func newBlockState(parentID string, b *notion.Block) (*BlockState, error) {
+	buf, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return nil, err
+	}
+	for _, v := range metadataFields {
+		delete(m, v)
+	}
+	if payload, ok := m[string(b.Type)]; ok {
+		if m[string(b.Type)], err = stripVolatileFields(payload); err != nil {
+			return nil, fmt.Errorf("watch: failed to read the content of %s: %v", b.ID, err)
+		}
+	}
+	// The keys of the map are sorted by json.Marshal. So that the content can be compared as bytes.
+	content, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	text, err := plainText(m[string(b.Type)])
+	if err != nil {
+		return nil, fmt.Errorf("watch: failed to read the text of %s: %v", b.ID, err)
+	}
+
+	return &BlockState{
+		ID:             b.ID,
+		ParentID:       parentID,
+		Type:           b.Type,
+		LastEditedTime: b.LastEditedTime.Time,
+		Content:        content,
+		Text:           text,
+	}, nil
+}
+
+// stripVolatileFields removes the fields which change on every fetch from the payload of the block.
+// The URL of the file hosted by Notion is signed and expires, so only the URL without the query is kept.
+func stripVolatileFields(payload json.RawMessage) (json.RawMessage, error) {
+	obj := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(payload, &obj); err != nil {
+		return nil, err
+	}
+
+	changed := false
+	if f, ok := obj["file"]; ok {
+		v, err := stripFileURL(f)
+		if err != nil {
+			return nil, err
+		}
+		obj["file"], changed = v, true
+	}
+	// The icon of callout may be the file hosted by Notion.
+	if icon, ok := obj["icon"]; ok {
+		v, err := stripVolatileFields(icon)
+		if err != nil {
+			return nil, err
+		}
+		obj["icon"], changed = v, true
+	}
+	if !changed {
+		return payload, nil
+	}
+	return json.Marshal(obj)
+}
+
+func stripFileURL(raw json.RawMessage) (json.RawMessage, error) {
+	f := &notion.NotionFile{}
+	if err := json.Unmarshal(raw, f); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(f.URL)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = ""
+	return json.Marshal(&notion.NotionFile{URL: u.String()})
+}
+
+// plainText returns the text of rich_text (or text for to_do) in the payload of the block.
+func plainText(payload json.RawMessage) (string, error) {
+	if len(payload) == 0 {
+		return "", nil
+	}
+	v := struct {
+		RichText []*notion.RichTextObject `json:"rich_text"`
+		Text     []*notion.RichTextObject `json:"text"`
+	}{}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return "", err
+	}
+	objs := v.RichText
+	if len(objs) == 0 {
+		objs = v.Text
+	}
+
+	var b strings.Builder
+	for _, o := range objs {
+		switch {
+		case o.PlainText != "":
+			b.WriteString(o.PlainText)
+		case o.Text != nil:
+			b.WriteString(o.Text.Content)
+		}
+	}
+	return b.String(), nil
+}
+
+// DiffSnapshots returns the changes from before to after.
+// The changes of after are ordered by the position in the page, and the deleted blocks follow them.
+func DiffSnapshots(before, after *Snapshot) []*BlockChange {
+	// The blocks which are reordered in the same parent.
+	reordered := make(map[string]struct{})
+	for parentID, children := range after.Children {
+		for _, id := range reorderedBlocks(before, parentID, children) {
+			reordered[id] = struct{}{}
+		}
+	}
+
+	var changes []*BlockChange
+	after.each(after.PageID, func(id string) {
+		cur := after.Blocks[id]
+		prev, ok := before.Blocks[id]
+		if !ok {
+			changes = append(changes, &BlockChange{Type: BlockChangeTypeInserted, BlockID: id, After: cur})
+			return
+		}
+		if _, ok := reordered[id]; ok || prev.ParentID != cur.ParentID {
+			changes = append(changes, &BlockChange{Type: BlockChangeTypeMoved, BlockID: id, Before: prev, After: cur})
+		}
+		if string(prev.Content) != string(cur.Content) {
+			changes = append(changes, &BlockChange{Type: BlockChangeTypeModified, BlockID: id, Before: prev, After: cur})
+		}
+	})
+	before.each(before.PageID, func(id string) {
+		if _, ok := after.Blocks[id]; !ok {
+			changes = append(changes, &BlockChange{Type: BlockChangeTypeDeleted, BlockID: id, Before: before.Blocks[id]})
+		}
+	})
+
+	return changes
+}
+
+// each calls fn for each block in the order of the page.
+func (s *Snapshot) each(parentID string, fn func(id string)) {
+	for _, id := range s.Children[parentID] {
+		fn(id)
+		s.each(id, fn)
+	}
+}
+
+// reorderedBlocks returns the blocks which are moved within the same parent.
+// The blocks which keep the relative order (the longest increasing subsequence of the previous positions) are not moved.
+func reorderedBlocks(before *Snapshot, parentID string, children []string) []string {
+	prevIndex := make(map[string]int)
+	for i, id := range before.Children[parentID] {
+		prevIndex[id] = i
+	}
+	var ids []string
+	var seq []int
+	for _, id := range children {
+		if i, ok := prevIndex[id]; ok {
+			ids = append(ids, id)
+			seq = append(seq, i)
+		}
+	}
+	if len(seq) < 2 {
+		return nil
+	}
+
+	keep := longestIncreasingSubsequence(seq)
+	var moved []string
+	for i, id := range ids {
+		if !keep[i] {
+			moved = append(moved, id)
+		}
+	}
+	return moved
+}
+
+// longestIncreasingSubsequence returns the flags of the elements which belong to the longest increasing subsequence.
+func longestIncreasingSubsequence(seq []int) []bool {
+	// tails[k] is the index of the smallest tail of the increasing subsequence which has length k+1.
+	tails := make([]int, 0, len(seq))
+	prev := make([]int, len(seq))
+	for i, v := range seq {
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if seq[tails[mid]] < v {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		if lo > 0 {
+			prev[i] = tails[lo-1]
+		} else {
+			prev[i] = -1
+		}
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+
+	keep := make([]bool, len(seq))
+	if len(tails) == 0 {
+		return keep
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+		keep[i] = true
+	}
+	return keep
+}
@@ -0,0 +1,177 @@
+package watch
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/notion-api/v3"
+)
+
+type fakePage struct {
+	mu   sync.Mutex
+	tree map[string][]*notion.Block
+}
+
+func (p *fakePage) Set(tree map[string][]*notion.Block) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.tree = tree
+}
+
+func (p *fakePage) Transport() *httpmock.MockTransport {
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodGet,
+		regexp.MustCompile(`/v1/blocks/[a-z0-9-]+/children`),
+		func(req *http.Request) (*http.Response, error) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+
+			id := strings.Split(req.URL.Path, "/")[3]
+			return httpmock.NewJsonResponse(http.StatusOK, &notion.BlockList{ListMeta: &notion.ListMeta{Object: notion.ObjectTypeList}, Results: p.tree[id]})
+		},
+	)
+	return rt
+}
+
+func paragraph(id, text string) *notion.Block {
+	return &notion.Block{
+		Meta:      &notion.Meta{Object: notion.ObjectTypeBlock, ID: id},
+		Type:      notion.BlockTypeParagraph,
+		Paragraph: &notion.Paragraph{RichText: []*notion.RichTextObject{{Type: notion.RichTextObjectTypeText, PlainText: text, Text: &notion.Text{Content: text}}}},
+	}
+}
+
+func toggle(id, text string, hasChildren bool) *notion.Block {
+	return &notion.Block{
+		Meta:        &notion.Meta{Object: notion.ObjectTypeBlock, ID: id},
+		Type:        notion.BlockTypeToggle,
+		HasChildren: hasChildren,
+		Toggle:      &notion.Paragraph{RichText: []*notion.RichTextObject{{Type: notion.RichTextObjectTypeText, PlainText: text, Text: &notion.Text{Content: text}}}},
+	}
+}
+
+func TestContentWatcher(t *testing.T) {
+	t.Parallel()
+
+	page := &fakePage{}
+	page.Set(map[string][]*notion.Block{
+		"page": {
+			paragraph("b1", "Intro"),
+			toggle("b2", "Details", true),
+			paragraph("b4", "Outro"),
+			paragraph("b5", "Extra"),
+		},
+		"b2": {paragraph("b3", "Nested")},
+	})
+	client, err := notion.New(&http.Client{Transport: page.Transport()}, "https://example.com")
+	require.NoError(t, err)
+	w := NewContentWatcher(client, NewDirSnapshotStore(t.TempDir()))
+
+	changes, err := w.Poll(context.Background(), "page")
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	page.Set(map[string][]*notion.Block{
+		"page": {
+			paragraph("b1", "Introduction"),
+			paragraph("b5", "Extra"),
+			toggle("b2", "Details", true),
+			paragraph("b3", "Nested"),
+		},
+		"b2": {paragraph("b6", "New")},
+	})
+	changes, err = w.Poll(context.Background(), "page")
+	require.NoError(t, err)
+
+	type change struct {
+		Type    BlockChangeType
+		BlockID string
+	}
+	var got []change
+	for _, v := range changes {
+		got = append(got, change{Type: v.Type, BlockID: v.BlockID})
+	}
+	assert.Equal(t, []change{
+		{Type: BlockChangeTypeModified, BlockID: "b1"},
+		{Type: BlockChangeTypeMoved, BlockID: "b5"},
+		{Type: BlockChangeTypeInserted, BlockID: "b6"},
+		{Type: BlockChangeTypeMoved, BlockID: "b3"},
+		{Type: BlockChangeTypeDeleted, BlockID: "b4"},
+	}, got)
+
+	assert.Equal(t, "Intro", changes[0].Before.Text)
+	assert.Equal(t, "Introduction", changes[0].After.Text)
+	assert.Equal(t, "b2", changes[2].After.ParentID)
+	assert.Equal(t, "New", changes[2].After.Text)
+	assert.Equal(t, "b2", changes[3].Before.ParentID)
+	assert.Equal(t, "page", changes[3].After.ParentID)
+	assert.Equal(t, "Outro", changes[4].Before.Text)
+
+	changes, err = w.Poll(context.Background(), "page")
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestContentWatcher_FileURL(t *testing.T) {
+	t.Parallel()
+
+	image := func(url string) *notion.Block {
+		return &notion.Block{
+			Meta: &notion.Meta{Object: notion.ObjectTypeBlock, ID: "b1"},
+			Type: notion.BlockTypeImage,
+			Image: &notion.FileBlock{
+				Type: notion.FileTypeFile,
+				File: &notion.NotionFile{URL: url, ExpiryTime: &notion.Time{Time: time.Now().Add(time.Hour)}},
+			},
+		}
+	}
+	page := &fakePage{}
+	page.Set(map[string][]*notion.Block{"page": {image("https://prod-files-secure.s3.us-west-2.amazonaws.com/a/image.png?X-Amz-Signature=1")}})
+	client, err := notion.New(&http.Client{Transport: page.Transport()}, "https://example.com")
+	require.NoError(t, err)
+	w := NewContentWatcher(client, nil)
+
+	_, err = w.Poll(context.Background(), "page")
+	require.NoError(t, err)
+
+	// The signed URL is changed on every fetch.
+	page.Set(map[string][]*notion.Block{"page": {image("https://prod-files-secure.s3.us-west-2.amazonaws.com/a/image.png?X-Amz-Signature=2")}})
+	changes, err := w.Poll(context.Background(), "page")
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// The file is replaced.
+	page.Set(map[string][]*notion.Block{"page": {image("https://prod-files-secure.s3.us-west-2.amazonaws.com/b/image.png?X-Amz-Signature=3")}})
+	changes, err = w.Poll(context.Background(), "page")
+	require.NoError(t, err)
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, BlockChangeTypeModified, changes[0].Type)
+	}
+}
+
+func TestLongestIncreasingSubsequence(t *testing.T) {
+	cases := []struct {
+		Seq  []int
+		Keep []bool
+	}{
+		{Seq: []int{0, 1, 2}, Keep: []bool{true, true, true}},
+		{Seq: []int{2, 0, 1}, Keep: []bool{false, true, true}},
+		{Seq: []int{0, 4, 1, 2}, Keep: []bool{true, false, true, true}},
+		{Seq: []int{3, 2, 1, 0}, Keep: []bool{false, false, false, true}},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.Keep, longestIncreasingSubsequence(tc.Seq), "%v", tc.Seq)
+	}
+}
@@ -0,0 +1,175 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists the checkpoint of the poller.
+type Store interface {
+	// Load returns the saved checkpoint. If there is no checkpoint, Load returns nil.
+	Load(ctx context.Context) (*Checkpoint, error)
+	Save(ctx context.Context, cp *Checkpoint) error
+}
+
+// MemoryStore keeps the checkpoint in memory.
+type MemoryStore struct {
+	mu         sync.Mutex
+	checkpoint *Checkpoint
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Load(_ context.Context) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.checkpoint == nil {
+		return nil, nil
+	}
+	return s.checkpoint.clone(), nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, cp *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoint = cp.clone()
+	return nil
+}
+
+// FileStore saves the checkpoint to the file as JSON.
+type FileStore struct {
+	Path string
+}
+
+var _ Store = (*FileStore)(nil)
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{Path: path}
+}
+
+func (s *FileStore) Load(_ context.Context) (*Checkpoint, error) {
+	buf, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cp := &Checkpoint{}
+	if err := json.Unmarshal(buf, cp); err != nil {
+		return nil, fmt.Errorf("watch: failed to decode the checkpoint: %v", err)
+	}
+	return cp, nil
+}
+
+// Save writes the checkpoint to the temporary file and renames it so that the checkpoint is never broken.
+func (s *FileStore) Save(_ context.Context, cp *Checkpoint) error {
+	buf, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("watch: failed to encode the checkpoint: %v", err)
+	}
+
+	return writeFile(s.Path, buf)
+}
+
+// writeFile writes the data to the temporary file and renames it so that the file is never broken.
+func writeFile(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), name); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
+
+// MemorySnapshotStore keeps the snapshots in memory.
+type MemorySnapshotStore struct {
+	mu        sync.Mutex
+	snapshots map[string]*Snapshot
+}
+
+var _ SnapshotStore = (*MemorySnapshotStore)(nil)
+
+func NewMemorySnapshotStore() *MemorySnapshotStore {
+	return &MemorySnapshotStore{snapshots: make(map[string]*Snapshot)}
+}
+
+func (s *MemorySnapshotStore) LoadSnapshot(_ context.Context, pageID string) (*Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.snapshots[pageID], nil
+}
+
+func (s *MemorySnapshotStore) SaveSnapshot(_ context.Context, snapshot *Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshots[snapshot.PageID] = snapshot
+	return nil
+}
+
+// DirSnapshotStore saves the snapshot of each page to the file under Dir.
+type DirSnapshotStore struct {
+	Dir string
+}
+
+var _ SnapshotStore = (*DirSnapshotStore)(nil)
+
+func NewDirSnapshotStore(dir string) *DirSnapshotStore {
+	return &DirSnapshotStore{Dir: dir}
+}
+
+func (s *DirSnapshotStore) LoadSnapshot(_ context.Context, pageID string) (*Snapshot, error) {
+	buf, err := os.ReadFile(s.path(pageID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(buf, snapshot); err != nil {
+		return nil, fmt.Errorf("watch: failed to decode the snapshot: %v", err)
+	}
+	return snapshot, nil
+}
+
+func (s *DirSnapshotStore) SaveSnapshot(_ context.Context, snapshot *Snapshot) error {
+	buf, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("watch: failed to encode the snapshot: %v", err)
+	}
+	return writeFile(s.path(snapshot.PageID), buf)
+}
+
+func (s *DirSnapshotStore) path(pageID string) string {
+	return filepath.Join(s.Dir, filepath.Base(pageID)+".json")
+}
@@ -0,0 +1,323 @@
+// Package watch provides the pollers which detect changes of pages.
+// Poller detects changes of properties of pages in a database, and ContentWatcher detects changes of blocks in a page.
+// The pollers are useful when the webhook is not available.
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.f110.dev/notion-api/v3"
+)
+
+const (
+	// DefaultOverlap is the default duration which is subtracted from the checkpoint when querying.
+	// last_edited_time of Notion is rounded to the minute, and the page may appear in the query result with a delay.
+	DefaultOverlap = 2 * time.Minute
+	// DefaultFullScanInterval is the default number of polls between the full scans.
+	DefaultFullScanInterval = 10
+)
+
+type EventType string
+
+const (
+	EventTypeCreated EventType = "created"
+	EventTypeUpdated EventType = "updated"
+	EventTypeRemoved EventType = "removed"
+)
+
+// Event is the change of the page.
+type Event struct {
+	Type   EventType
+	PageID string
+	// Page is the current page. Page is nil if Type is EventTypeRemoved.
+	Page *notion.Page
+	// Previous is the properties which are observed last time. Previous is nil if Type is EventTypeCreated.
+	Previous map[string]*notion.PropertyData
+	// Changes is the list of the changed properties, sorted by the name.
+	// Changes is empty if only the content of the page is updated.
+	Changes []*PropertyChange
+}
+
+// PropertyChange is the change of the property.
+type PropertyChange struct {
+	Name string
+	// Before is nil if the property is added.
+	Before *notion.PropertyData
+	// After is nil if the property is deleted.
+	After *notion.PropertyData
+}
+
+// HandlerFunc handles the event.
+// If HandlerFunc returns the error, the checkpoint is not advanced and the events will be emitted again.
+type HandlerFunc func(ctx context.Context, e *Event) error
+
+// Checkpoint is the state of the poller.
+type Checkpoint struct {
+	// LastEditedTime is the newest last_edited_time of the observed pages.
+	LastEditedTime time.Time `json:"last_edited_time"`
+	// Pages is the last observed state of the pages.
+	Pages map[string]*PageState `json:"pages"`
+}
+
+// PageState is the last observed state of the page.
+type PageState struct {
+	LastEditedTime time.Time                       `json:"last_edited_time"`
+	Properties     map[string]*notion.PropertyData `json:"properties"`
+}
+
+func (c *Checkpoint) clone() *Checkpoint {
+	n := &Checkpoint{LastEditedTime: c.LastEditedTime, Pages: make(map[string]*PageState, len(c.Pages))}
+	for k, v := range c.Pages {
+		n.Pages[k] = v
+	}
+	return n
+}
+
+type queryFunc func(ctx context.Context, filter *notion.Filter, sorts []*notion.Sort) ([]*notion.Page, error)
+
+// Poller polls the database and emits the changes of the pages.
+//
+// The poller queries the pages which are edited after the checkpoint, and compares them with the last observed state.
+// Thus the page is reported once even if the query results are overlapped.
+// The removed pages are detected only by the full scan because the query doesn't return them.
+type Poller struct {
+	query            queryFunc
+	store            Store
+	overlap          time.Duration
+	fullScanInterval int
+	emitExisting     bool
+
+	mu         sync.Mutex
+	checkpoint *Checkpoint
+	polls      int
+}
+
+type PollerOpt func(*Poller)
+
+// WithOverlap specifies the duration which is subtracted from the checkpoint when querying.
+// The duration should be larger than the clock skew between the servers of Notion.
+func WithOverlap(d time.Duration) PollerOpt {
+	return func(p *Poller) {
+		p.overlap = d
+	}
+}
+
+// WithFullScanInterval specifies the number of polls between the full scans.
+// The first poll is always the full scan. If n is 0, the full scan is performed only at the first poll.
+func WithFullScanInterval(n int) PollerOpt {
+	return func(p *Poller) {
+		p.fullScanInterval = n
+	}
+}
+
+// WithEmitExisting emits created events for the existing pages when there is no checkpoint.
+// By default, the first poll only records the existing pages.
+func WithEmitExisting() PollerOpt {
+	return func(p *Poller) {
+		p.emitExisting = true
+	}
+}
+
+// New returns the poller for the database.
+// Since 2025-09-03, the poller resolves the data source of the database at the first poll and polls the data source.
+// If the database has multiple data sources, the poll returns notion.ErrMultipleDataSources. Use NewDataSource instead.
+func New(client *notion.Client, databaseID string, store Store, opts ...PollerOpt) *Poller {
+	// The queries are serialized by Poller.mu, so dataSourceID doesn't need the lock.
+	var dataSourceID string
+	return newPoller(func(ctx context.Context, filter *notion.Filter, sorts []*notion.Sort) ([]*notion.Page, error) {
+		if client.Version() < notion.Version20250903 {
+			return client.GetPages(ctx, databaseID, filter, sorts)
+		}
+		if dataSourceID == "" {
+			id, err := client.ResolveDataSourceID(ctx, databaseID)
+			if err != nil {
+				return nil, fmt.Errorf("watch: failed to resolve the data source of %s (use NewDataSource for the data source): %w", databaseID, err)
+			}
+			dataSourceID = id
+		}
+		return client.QueryDataSource(ctx, dataSourceID, filter, sorts)
+	}, store, opts)
+}
+
+// NewDataSource returns the poller for the data source.
+// The data source is available since 2025-09-03.
+func NewDataSource(client *notion.Client, dataSourceID string, store Store, opts ...PollerOpt) *Poller {
+	return newPoller(func(ctx context.Context, filter *notion.Filter, sorts []*notion.Sort) ([]*notion.Page, error) {
+		return client.QueryDataSource(ctx, dataSourceID, filter, sorts)
+	}, store, opts)
+}
+
+func newPoller(query queryFunc, store Store, opts []PollerOpt) *Poller {
+	p := &Poller{query: query, store: store, overlap: DefaultOverlap, fullScanInterval: DefaultFullScanInterval}
+	for _, v := range opts {
+		v(p)
+	}
+	if p.store == nil {
+		p.store = NewMemoryStore()
+	}
+
+	return p
+}
+
+// Run polls the database every interval until the context is cancelled.
+func (p *Poller) Run(ctx context.Context, interval time.Duration, fn HandlerFunc) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Poll(ctx, fn); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll queries the database once and calls fn for each event.
+// The checkpoint is saved after all events are handled.
+func (p *Poller) Poll(ctx context.Context, fn HandlerFunc) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The checkpoint is kept only after the poll succeeds.
+	// Otherwise, the existing pages are reported as created when the first poll fails.
+	cp := p.checkpoint
+	baseline := false
+	if cp == nil {
+		loaded, err := p.store.Load(ctx)
+		if err != nil {
+			return err
+		}
+		if loaded == nil {
+			loaded = &Checkpoint{}
+			baseline = true
+		}
+		if loaded.Pages == nil {
+			loaded.Pages = make(map[string]*PageState)
+		}
+		cp = loaded
+	}
+
+	full := p.polls == 0 || (p.fullScanInterval > 0 && p.polls%p.fullScanInterval == 0)
+	sorts := []*notion.Sort{{Timestamp: string(notion.TimestampTypeLastEditedTime), Direction: "ascending"}}
+	var filter *notion.Filter
+	if !full {
+		filter = &notion.Filter{
+			Timestamp: &notion.TimestampFilter{
+				Timestamp:      notion.TimestampTypeLastEditedTime,
+				LastEditedTime: &notion.DateFilter{OnOrAfter: notion.NewDateTime(cp.LastEditedTime.Add(-p.overlap))},
+			},
+		}
+	}
+	pages, err := p.query(ctx, filter, sorts)
+	if err != nil {
+		return err
+	}
+
+	next := cp.clone()
+	var events []*Event
+	seen := make(map[string]struct{}, len(pages))
+	for _, page := range pages {
+		seen[page.ID] = struct{}{}
+		var lastEditedTime time.Time
+		if page.LastEditedTime != nil {
+			lastEditedTime = page.LastEditedTime.Time
+		}
+		if lastEditedTime.After(next.LastEditedTime) {
+			next.LastEditedTime = lastEditedTime
+		}
+
+		prev, ok := next.Pages[page.ID]
+		next.Pages[page.ID] = &PageState{LastEditedTime: lastEditedTime, Properties: page.Properties}
+		if !ok {
+			if !baseline || p.emitExisting {
+				events = append(events, &Event{Type: EventTypeCreated, PageID: page.ID, Page: page})
+			}
+			continue
+		}
+
+		changes, err := diff(prev.Properties, page.Properties)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 && prev.LastEditedTime.Equal(lastEditedTime) {
+			continue
+		}
+		events = append(events, &Event{Type: EventTypeUpdated, PageID: page.ID, Page: page, Previous: prev.Properties, Changes: changes})
+	}
+	if full {
+		var removed []string
+		for id := range cp.Pages {
+			if _, ok := seen[id]; !ok {
+				removed = append(removed, id)
+			}
+		}
+		sort.Strings(removed)
+		for _, id := range removed {
+			events = append(events, &Event{Type: EventTypeRemoved, PageID: id, Previous: cp.Pages[id].Properties})
+			delete(next.Pages, id)
+		}
+	}
+
+	for _, e := range events {
+		if err := fn(ctx, e); err != nil {
+			return err
+		}
+	}
+	if err := p.store.Save(ctx, next); err != nil {
+		return err
+	}
+	p.checkpoint = next
+	p.polls++
+
+	return nil
+}
+
+// diff returns the changed properties. The properties are compared by JSON representation.
+func diff(before, after map[string]*notion.PropertyData) ([]*PropertyChange, error) {
+	var changes []*PropertyChange
+	for k, v := range after {
+		prev, ok := before[k]
+		if !ok {
+			changes = append(changes, &PropertyChange{Name: k, After: v})
+			continue
+		}
+		equal, err := equalProperty(prev, v)
+		if err != nil {
+			return nil, err
+		}
+		if !equal {
+			changes = append(changes, &PropertyChange{Name: k, Before: prev, After: v})
+		}
+	}
+	for k, v := range before {
+		if _, ok := after[k]; !ok {
+			changes = append(changes, &PropertyChange{Name: k, Before: v})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+
+	return changes, nil
+}
+
+func equalProperty(a, b *notion.PropertyData) (bool, error) {
+	x, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+	y, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(x, y), nil
+}
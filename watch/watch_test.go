@@ -0,0 +1,256 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/notion-api/v3"
+)
+
+type fakeDatabase struct {
+	mu      sync.Mutex
+	pages   map[string]*notion.Page
+	filters []*notion.Filter
+	// failures is the number of the requests which will fail.
+	failures int
+}
+
+func newFakeDatabase() *fakeDatabase {
+	return &fakeDatabase{pages: make(map[string]*notion.Page)}
+}
+
+func (d *fakeDatabase) Put(id, name string, lastEditedTime time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.pages[id] = &notion.Page{
+		Meta:           &notion.Meta{Object: notion.ObjectTypePage, ID: id},
+		LastEditedTime: &notion.Time{Time: lastEditedTime},
+		Properties: map[string]*notion.PropertyData{
+			"Name": {
+				ID:    "title",
+				Type:  notion.PropertyTypeTitle,
+				Title: []*notion.RichTextObject{{Type: notion.RichTextObjectTypeText, PlainText: name, Text: &notion.Text{Content: name}}},
+			},
+		},
+	}
+}
+
+func (d *fakeDatabase) Delete(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.pages, id)
+}
+
+func (d *fakeDatabase) Transport() *httpmock.MockTransport {
+	rt := httpmock.NewMockTransport()
+	rt.RegisterRegexpResponder(
+		http.MethodPost,
+		regexp.MustCompile(`/v1/(databases|data_sources)/[a-z0-9-]+/query$`),
+		func(req *http.Request) (*http.Response, error) {
+			body := struct {
+				Filter *notion.Filter `json:"filter"`
+				Sorts  []*notion.Sort `json:"sorts"`
+			}{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			if d.failures > 0 {
+				d.failures--
+				return httpmock.NewStringResponse(http.StatusInternalServerError, `{"object":"error","status":500,"code":"internal_server_error","message":"Unexpected error"}`), nil
+			}
+			d.filters = append(d.filters, body.Filter)
+			var results []*notion.Page
+			for _, v := range d.pages {
+				if body.Filter != nil && v.LastEditedTime.Before(body.Filter.Timestamp.LastEditedTime.OnOrAfter.Time) {
+					continue
+				}
+				results = append(results, v)
+			}
+			sort.Slice(results, func(i, j int) bool {
+				if results[i].LastEditedTime.Equal(results[j].LastEditedTime.Time) {
+					return results[i].ID < results[j].ID
+				}
+				return results[i].LastEditedTime.Before(results[j].LastEditedTime.Time)
+			})
+			return httpmock.NewJsonResponse(http.StatusOK, &notion.PageList{ListMeta: &notion.ListMeta{Object: notion.ObjectTypeList}, Results: results})
+		},
+	)
+	return rt
+}
+
+func (d *fakeDatabase) LastFilter() *notion.Filter {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.filters[len(d.filters)-1]
+}
+
+type recorder struct {
+	events []*Event
+}
+
+func (r *recorder) Handle(_ context.Context, e *Event) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *recorder) Take() []*Event {
+	e := r.events
+	r.events = nil
+	return e
+}
+
+func TestPoller(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	db := newFakeDatabase()
+	db.Put("page-a", "a", base)
+	db.Put("page-b", "b", base)
+	client, err := notion.New(&http.Client{Transport: db.Transport()}, "https://example.com")
+	require.NoError(t, err)
+	store := NewFileStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	rec := &recorder{}
+	ctx := context.Background()
+
+	p := New(client, "database", store, WithFullScanInterval(0))
+	// The first poll records the existing pages.
+	require.NoError(t, p.Poll(ctx, rec.Handle))
+	assert.Empty(t, rec.Take())
+	assert.Nil(t, db.LastFilter())
+
+	db.Put("page-a", "a2", base.Add(time.Minute))
+	db.Put("page-c", "c", base.Add(time.Minute))
+	require.NoError(t, p.Poll(ctx, rec.Handle))
+	if f := db.LastFilter(); assert.NotNil(t, f) {
+		assert.Equal(t, notion.TimestampTypeLastEditedTime, f.Timestamp.Timestamp)
+		assert.True(t, base.Add(-DefaultOverlap).Equal(f.Timestamp.LastEditedTime.OnOrAfter.Time))
+	}
+	events := rec.Take()
+	require.Len(t, events, 2)
+	assert.Equal(t, EventTypeUpdated, events[0].Type)
+	assert.Equal(t, "page-a", events[0].PageID)
+	require.Len(t, events[0].Changes, 1)
+	assert.Equal(t, "Name", events[0].Changes[0].Name)
+	assert.Equal(t, "a", events[0].Changes[0].Before.String())
+	assert.Equal(t, "a2", events[0].Changes[0].After.String())
+	assert.Equal(t, EventTypeCreated, events[1].Type)
+	assert.Equal(t, "page-c", events[1].PageID)
+
+	// The page is updated again in the same minute. last_edited_time is not changed.
+	db.Put("page-a", "a3", base.Add(time.Minute))
+	require.NoError(t, p.Poll(ctx, rec.Handle))
+	events = rec.Take()
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTypeUpdated, events[0].Type)
+	assert.Equal(t, "a3", events[0].Changes[0].After.String())
+
+	// Nothing is changed.
+	require.NoError(t, p.Poll(ctx, rec.Handle))
+	assert.Empty(t, rec.Take())
+
+	// The events are emitted again if the handler fails.
+	db.Put("page-b", "b2", base.Add(2*time.Minute))
+	err = p.Poll(ctx, func(_ context.Context, _ *Event) error { return errors.New("failure") })
+	require.Error(t, err)
+	require.NoError(t, p.Poll(ctx, rec.Handle))
+	events = rec.Take()
+	require.Len(t, events, 1)
+	assert.Equal(t, "page-b", events[0].PageID)
+
+	// The new poller resumes from the checkpoint and detects the removed page by the full scan.
+	db.Delete("page-c")
+	db.Put("page-a", "a4", base.Add(3*time.Minute))
+	p = New(client, "database", store)
+	require.NoError(t, p.Poll(ctx, rec.Handle))
+	assert.Nil(t, db.LastFilter())
+	events = rec.Take()
+	require.Len(t, events, 2)
+	assert.Equal(t, EventTypeUpdated, events[0].Type)
+	assert.Equal(t, "page-a", events[0].PageID)
+	assert.Equal(t, "a3", events[0].Changes[0].Before.String())
+	assert.Equal(t, EventTypeRemoved, events[1].Type)
+	assert.Equal(t, "page-c", events[1].PageID)
+	assert.Nil(t, events[1].Page)
+	assert.Equal(t, "c", events[1].Previous["Name"].String())
+
+	cp, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.True(t, base.Add(3*time.Minute).Equal(cp.LastEditedTime))
+	assert.Len(t, cp.Pages, 2)
+}
+
+func TestPoller_EmitExisting(t *testing.T) {
+	t.Parallel()
+
+	db := newFakeDatabase()
+	db.Put("page-a", "a", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	client, err := notion.New(&http.Client{Transport: db.Transport()}, "https://example.com")
+	require.NoError(t, err)
+
+	rec := &recorder{}
+	p := New(client, "database", nil, WithEmitExisting())
+	require.NoError(t, p.Poll(context.Background(), rec.Handle))
+	events := rec.Take()
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTypeCreated, events[0].Type)
+	assert.Equal(t, "a", events[0].Page.Properties["Name"].String())
+}
+
+func TestPoller_DataSource(t *testing.T) {
+	t.Parallel()
+
+	db := newFakeDatabase()
+	db.Put("page-a", "a", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	rt := db.Transport()
+	rt.RegisterResponder(
+		http.MethodGet,
+		"https://example.com/v1/databases/database",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{"object": "database", "id": "database", "data_sources": []map[string]any{{"id": "data-source"}}}),
+	)
+	client, err := notion.New(&http.Client{Transport: rt}, "https://example.com", notion.WithVersion(notion.Version20250903))
+	require.NoError(t, err)
+
+	rec := &recorder{}
+	p := New(client, "database", nil, WithEmitExisting())
+	require.NoError(t, p.Poll(context.Background(), rec.Handle))
+	require.NoError(t, p.Poll(context.Background(), rec.Handle))
+	assert.Len(t, rec.Take(), 1)
+	calls := rt.GetCallCountInfo()
+	// The data source is resolved only once.
+	assert.Equal(t, 1, calls["GET https://example.com/v1/databases/database"])
+	assert.Equal(t, 2, calls[`POST =~/v1/(databases|data_sources)/[a-z0-9-]+/query$`])
+}
+
+func TestPoller_FirstPollFailed(t *testing.T) {
+	t.Parallel()
+
+	db := newFakeDatabase()
+	db.Put("page-a", "a", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	db.failures = 1
+	client, err := notion.New(&http.Client{Transport: db.Transport()}, "https://example.com")
+	require.NoError(t, err)
+
+	rec := &recorder{}
+	p := New(client, "database", nil)
+	require.Error(t, p.Poll(context.Background(), rec.Handle))
+	require.NoError(t, p.Poll(context.Background(), rec.Handle))
+	assert.Empty(t, rec.Take())
+}
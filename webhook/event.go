@@ -0,0 +1,155 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTypePageCreated              EventType = "page.created"
+	EventTypePagePropertiesUpdated    EventType = "page.properties_updated"
+	EventTypePageContentUpdated       EventType = "page.content_updated"
+	EventTypePageMoved                EventType = "page.moved"
+	EventTypePageDeleted              EventType = "page.deleted"
+	EventTypePageUndeleted            EventType = "page.undeleted"
+	EventTypePageLocked               EventType = "page.locked"
+	EventTypePageUnlocked             EventType = "page.unlocked"
+	EventTypeDatabaseCreated          EventType = "database.created"
+	EventTypeDatabaseContentUpdated   EventType = "database.content_updated"
+	EventTypeDatabaseMoved            EventType = "database.moved"
+	EventTypeDatabaseDeleted          EventType = "database.deleted"
+	EventTypeDatabaseUndeleted        EventType = "database.undeleted"
+	EventTypeDatabaseSchemaUpdated    EventType = "database.schema_updated"
+	EventTypeDataSourceCreated        EventType = "data_source.created"
+	EventTypeDataSourceContentUpdated EventType = "data_source.content_updated"
+	EventTypeDataSourceMoved          EventType = "data_source.moved"
+	EventTypeDataSourceDeleted        EventType = "data_source.deleted"
+	EventTypeDataSourceUndeleted      EventType = "data_source.undeleted"
+	EventTypeDataSourceSchemaUpdated  EventType = "data_source.schema_updated"
+	EventTypeCommentCreated           EventType = "comment.created"
+	EventTypeCommentUpdated           EventType = "comment.updated"
+	EventTypeCommentDeleted           EventType = "comment.deleted"
+)
+
+type EntityType string
+
+const (
+	EntityTypePage       EntityType = "page"
+	EntityTypeDatabase   EntityType = "database"
+	EntityTypeDataSource EntityType = "data_source"
+	EntityTypeBlock      EntityType = "block"
+	EntityTypeComment    EntityType = "comment"
+	EntityTypeSpace      EntityType = "space"
+)
+
+// Event is the event which is delivered by the webhook.
+// ref: https://developers.notion.com/reference/webhooks-events-delivery
+type Event struct {
+	ID             string    `json:"id"`
+	Timestamp      time.Time `json:"timestamp"`
+	WorkspaceID    string    `json:"workspace_id"`
+	WorkspaceName  string    `json:"workspace_name"`
+	SubscriptionID string    `json:"subscription_id"`
+	IntegrationID  string    `json:"integration_id"`
+	Type           EventType `json:"type"`
+	Authors        []*Author `json:"authors"`
+	AccessibleBy   []*Author `json:"accessible_by,omitempty"`
+	AttemptNumber  int       `json:"attempt_number"`
+	Entity         *Entity   `json:"entity"`
+	// Data is the typed payload of the event.
+	// The type of Data is determined by Type (e.g. *PagePropertiesUpdated for page.properties_updated).
+	// If Type is unknown, Data is nil and the payload is available in RawData.
+	Data    interface{}     `json:"-"`
+	RawData json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *Event) UnmarshalJSON(b []byte) error {
+	type event Event
+	v := (*event)(e)
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+
+	var data interface{}
+	switch e.Type {
+	case EventTypePageCreated, EventTypePageMoved, EventTypePageDeleted, EventTypePageUndeleted,
+		EventTypePageLocked, EventTypePageUnlocked,
+		EventTypeDatabaseCreated, EventTypeDatabaseMoved, EventTypeDatabaseDeleted, EventTypeDatabaseUndeleted,
+		EventTypeDataSourceCreated, EventTypeDataSourceMoved, EventTypeDataSourceDeleted, EventTypeDataSourceUndeleted:
+		data = &EntityChanged{}
+	case EventTypePagePropertiesUpdated:
+		data = &PagePropertiesUpdated{}
+	case EventTypePageContentUpdated, EventTypeDatabaseContentUpdated, EventTypeDataSourceContentUpdated:
+		data = &ContentUpdated{}
+	case EventTypeDatabaseSchemaUpdated, EventTypeDataSourceSchemaUpdated:
+		data = &SchemaUpdated{}
+	case EventTypeCommentCreated, EventTypeCommentUpdated, EventTypeCommentDeleted:
+		data = &CommentChanged{}
+	default:
+		return nil
+	}
+	if len(e.RawData) > 0 {
+		if err := json.Unmarshal(e.RawData, data); err != nil {
+			return fmt.Errorf("webhook: failed to decode the data of %s: %v", e.Type, err)
+		}
+	}
+	e.Data = data
+
+	return nil
+}
+
+type Author struct {
+	ID string `json:"id"`
+	// Type is "person", "bot" or "agent".
+	Type string `json:"type"`
+}
+
+type Entity struct {
+	ID   string     `json:"id"`
+	Type EntityType `json:"type"`
+}
+
+// EntityChanged is the data of the events that the page, the database or the data source is created, moved, deleted and so on.
+type EntityChanged struct {
+	Parent *Entity `json:"parent"`
+}
+
+type PagePropertiesUpdated struct {
+	Parent *Entity `json:"parent"`
+	// UpdatedProperties is the list of the property ID.
+	UpdatedProperties []string `json:"updated_properties"`
+}
+
+// ContentUpdated is the data of page.content_updated, database.content_updated and data_source.content_updated.
+type ContentUpdated struct {
+	Parent        *Entity   `json:"parent"`
+	UpdatedBlocks []*Entity `json:"updated_blocks"`
+}
+
+type SchemaUpdated struct {
+	Parent            *Entity           `json:"parent"`
+	UpdatedProperties []*PropertyChange `json:"updated_properties"`
+}
+
+type PropertyAction string
+
+const (
+	PropertyActionCreated PropertyAction = "created"
+	PropertyActionUpdated PropertyAction = "updated"
+	PropertyActionDeleted PropertyAction = "deleted"
+)
+
+type PropertyChange struct {
+	ID     string         `json:"id"`
+	Name   string         `json:"name"`
+	Action PropertyAction `json:"action"`
+}
+
+// CommentChanged is the data of comment.created, comment.updated and comment.deleted.
+type CommentChanged struct {
+	PageID string  `json:"page_id"`
+	Parent *Entity `json:"parent"`
+}
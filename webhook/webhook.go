@@ -0,0 +1,278 @@
+// Package webhook provides the receiver of the webhook of Notion.
+// ref: https://developers.notion.com/reference/webhooks
+package webhook
+
+import (
+	"container/list"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// SignatureHeader is the header which has the signature of the request body.
+	SignatureHeader = "X-Notion-Signature"
+	// DefaultStoreSize is the number of event IDs which are remembered by the default store.
+	DefaultStoreSize = 10000
+
+	maxBodySize = 1 << 20
+)
+
+// ErrInvalidSignature is returned when the signature of the request doesn't match.
+var ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+// HandlerFunc handles the event.
+// If HandlerFunc returns the error, Handler responds 500 and Notion retries the delivery.
+type HandlerFunc func(ctx context.Context, e *Event) error
+
+// VerificationFunc receives the verification token when the subscription is created.
+// The token has to be entered on the integration settings to finish the verification.
+// The verification request is not signed. Don't apply the token to the handler without confirming it.
+type VerificationFunc func(ctx context.Context, token string) error
+
+// Store records the ID of the delivered events for deduplication.
+type Store interface {
+	// Add records the ID. Add returns false if the ID has been already recorded.
+	Add(ctx context.Context, id string) (bool, error)
+	// Remove forgets the ID so that the event can be handled again.
+	Remove(ctx context.Context, id string) error
+}
+
+// Handler is the http.Handler which receives the webhook.
+type Handler struct {
+	store        Store
+	verification VerificationFunc
+
+	mu       sync.RWMutex
+	token    string
+	handlers map[EventType][]HandlerFunc
+	any      []HandlerFunc
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+type HandlerOpt func(*Handler)
+
+// WithStore specifies the store for deduplication.
+// The default store is the in-memory store which remembers DefaultStoreSize events.
+func WithStore(s Store) HandlerOpt {
+	return func(h *Handler) {
+		h.store = s
+	}
+}
+
+// WithVerification specifies the function which receives the verification token.
+func WithVerification(f VerificationFunc) HandlerOpt {
+	return func(h *Handler) {
+		h.verification = f
+	}
+}
+
+// NewHandler returns the new Handler.
+// verificationToken is used for validating the signature of the request.
+// verificationToken can be empty until the subscription is verified. In this case, all events are rejected.
+func NewHandler(verificationToken string, opts ...HandlerOpt) *Handler {
+	h := &Handler{token: verificationToken, handlers: make(map[EventType][]HandlerFunc)}
+	for _, v := range opts {
+		v(h)
+	}
+	if h.store == nil {
+		h.store = NewMemoryStore(DefaultStoreSize)
+	}
+
+	return h
+}
+
+// SetVerificationToken replaces the verification token.
+func (h *Handler) SetVerificationToken(token string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.token = token
+}
+
+// On registers the handler for the type of the event.
+func (h *Handler) On(t EventType, f HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[t] = append(h.handlers[t], f)
+}
+
+// OnAny registers the handler for all events.
+func (h *Handler) OnAny(f HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.any = append(h.any, f)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxBodySize+1))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxBodySize {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// The verification request is sent before the token is known. So it has no signature.
+	verification := struct {
+		VerificationToken string `json:"verification_token"`
+	}{}
+	if err := json.Unmarshal(body, &verification); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if verification.VerificationToken != "" {
+		if h.verification != nil {
+			if err := h.verification(req.Context(), verification.VerificationToken); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := h.Verify(body, req.Header.Get(SignatureHeader)); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	e := &Event{}
+	if err := json.Unmarshal(body, e); err != nil || e.ID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Dispatch(req.Context(), e); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Verify validates the signature of the request body.
+// signature is the value of X-Notion-Signature header.
+func (h *Handler) Verify(body []byte, signature string) error {
+	h.mu.RLock()
+	token := h.token
+	h.mu.RUnlock()
+	if token == "" {
+		return errors.New("webhook: verification token is not set")
+	}
+
+	hexSig, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	sig, err := hex.DecodeString(hexSig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal(sig, Sign(token, body)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// Dispatch calls the handlers of the event.
+// The event which has been already handled is ignored.
+// If the handler fails, the event is forgotten so that the retried delivery will be handled.
+func (h *Handler) Dispatch(ctx context.Context, e *Event) error {
+	added, err := h.store.Add(ctx, e.ID)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return nil
+	}
+
+	h.mu.RLock()
+	handlers := append(append([]HandlerFunc{}, h.handlers[e.Type]...), h.any...)
+	h.mu.RUnlock()
+	for _, f := range handlers {
+		if err := f(ctx, e); err != nil {
+			if rErr := h.store.Remove(ctx, e.ID); rErr != nil {
+				return errors.Join(err, rErr)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sign returns HMAC-SHA256 of the body.
+func Sign(token string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// SignatureValue returns the value of X-Notion-Signature header for the body.
+func SignatureValue(token string, body []byte) string {
+	return "sha256=" + hex.EncodeToString(Sign(token, body))
+}
+
+// MemoryStore is the in-memory Store which remembers the recent event IDs.
+type MemoryStore struct {
+	size int
+
+	mu    sync.Mutex
+	ids   map[string]*list.Element
+	order *list.List
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore returns the MemoryStore which remembers size event IDs.
+func NewMemoryStore(size int) *MemoryStore {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryStore{size: size, ids: make(map[string]*list.Element), order: list.New()}
+}
+
+func (s *MemoryStore) Add(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.ids[id]; ok {
+		return false, nil
+	}
+	s.ids[id] = s.order.PushBack(id)
+	for s.order.Len() > s.size {
+		oldest := s.order.Front()
+		s.order.Remove(oldest)
+		delete(s.ids, oldest.Value.(string))
+	}
+
+	return true, nil
+}
+
+func (s *MemoryStore) Remove(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.ids[id]; ok {
+		s.order.Remove(e)
+		delete(s.ids, id)
+	}
+
+	return nil
+}
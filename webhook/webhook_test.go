@@ -0,0 +1,212 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	const token = "secret_tMrlL1qK5vuQAh1b6cZGhFChZTSYJlce98V0pYn7yBl"
+	send := func(h http.Handler, body []byte, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+		if signature != "" {
+			req.Header.Set(SignatureHeader, signature)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("Verification", func(t *testing.T) {
+		t.Parallel()
+
+		var received string
+		h := NewHandler("", WithVerification(func(_ context.Context, token string) error {
+			received = token
+			return nil
+		}))
+		code := send(h, []byte(`{"verification_token":"`+token+`"}`), "")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, token, received)
+
+		// Events are rejected until the verification token is set.
+		body, err := os.ReadFile("./testdata/page-properties-updated.json")
+		require.NoError(t, err)
+		code = send(h, body, SignatureValue(token, body))
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		h.SetVerificationToken(received)
+		code = send(h, body, SignatureValue(token, body))
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Signature", func(t *testing.T) {
+		t.Parallel()
+
+		called := 0
+		h := NewHandler(token)
+		h.OnAny(func(_ context.Context, _ *Event) error {
+			called++
+			return nil
+		})
+		body, err := os.ReadFile("./testdata/page-properties-updated.json")
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, send(h, body, ""))
+		assert.Equal(t, http.StatusUnauthorized, send(h, body, SignatureValue("other", body)))
+		assert.Equal(t, http.StatusUnauthorized, send(h, body, "sha256=xyz"))
+		tampered := bytes.Replace(body, []byte(`"attempt_number": 1`), []byte(`"attempt_number": 2`), 1)
+		assert.Equal(t, http.StatusUnauthorized, send(h, tampered, SignatureValue(token, body)))
+		assert.Equal(t, 0, called)
+
+		assert.Equal(t, http.StatusOK, send(h, body, SignatureValue(token, body)))
+		assert.Equal(t, 1, called)
+
+		req := httptest.NewRequest(http.MethodGet, "/webhook", nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("Dispatch", func(t *testing.T) {
+		t.Parallel()
+
+		var mu sync.Mutex
+		events := make(map[EventType]*Event)
+		var all []EventType
+		h := NewHandler(token)
+		for _, v := range []EventType{EventTypePagePropertiesUpdated, EventTypeDatabaseSchemaUpdated, EventTypeCommentCreated} {
+			h.On(v, func(_ context.Context, e *Event) error {
+				mu.Lock()
+				defer mu.Unlock()
+				events[e.Type] = e
+				return nil
+			})
+		}
+		h.OnAny(func(_ context.Context, e *Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			all = append(all, e.Type)
+			return nil
+		})
+
+		for _, v := range []string{"page-properties-updated.json", "database-schema-updated.json", "comment-created.json"} {
+			body, err := os.ReadFile("./testdata/" + v)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, send(h, body, SignatureValue(token, body)))
+		}
+		assert.Equal(t, []EventType{EventTypePagePropertiesUpdated, EventTypeDatabaseSchemaUpdated, EventTypeCommentCreated}, all)
+
+		e := events[EventTypePagePropertiesUpdated]
+		require.NotNil(t, e)
+		assert.Equal(t, "367cba44-b6f3-4c92-81e7-6a2e9659efd4", e.ID)
+		assert.Equal(t, time.Date(2024, 12, 5, 23, 55, 34, 285000000, time.UTC), e.Timestamp)
+		assert.Equal(t, "Quantify Labs", e.WorkspaceName)
+		assert.Equal(t, EntityTypePage, e.Entity.Type)
+		assert.Len(t, e.AccessibleBy, 2)
+		if assert.IsType(t, &PagePropertiesUpdated{}, e.Data) {
+			data := e.Data.(*PagePropertiesUpdated)
+			assert.Equal(t, EntityTypeSpace, data.Parent.Type)
+			assert.Equal(t, []string{"XGe%40", "bDf%5B", "DbAu"}, data.UpdatedProperties)
+		}
+
+		e = events[EventTypeDatabaseSchemaUpdated]
+		require.NotNil(t, e)
+		assert.Equal(t, 2, e.AttemptNumber)
+		if assert.IsType(t, &SchemaUpdated{}, e.Data) {
+			data := e.Data.(*SchemaUpdated)
+			require.Len(t, data.UpdatedProperties, 2)
+			assert.Equal(t, "Priority", data.UpdatedProperties[0].Name)
+			assert.Equal(t, PropertyActionCreated, data.UpdatedProperties[0].Action)
+			assert.Equal(t, PropertyActionDeleted, data.UpdatedProperties[1].Action)
+		}
+
+		e = events[EventTypeCommentCreated]
+		require.NotNil(t, e)
+		if assert.IsType(t, &CommentChanged{}, e.Data) {
+			data := e.Data.(*CommentChanged)
+			assert.Equal(t, "0ef104cd-477e-80e1-8571-cf9d8b3ea15e", data.PageID)
+			assert.Equal(t, EntityTypePage, data.Parent.Type)
+		}
+	})
+
+	t.Run("UnknownType", func(t *testing.T) {
+		t.Parallel()
+
+		var received *Event
+		h := NewHandler(token)
+		h.OnAny(func(_ context.Context, e *Event) error {
+			received = e
+			return nil
+		})
+		body := []byte(`{"id":"a3c8d1e4-0a0b-4c8f-9d4e-1f2a3b4c5d6e","type":"page.archived_by_robot","entity":{"id":"153104cd-477e-809d-8dc4-ff2d96ae3090","type":"page"},"data":{"foo":"bar"}}`)
+		assert.Equal(t, http.StatusOK, send(h, body, SignatureValue(token, body)))
+		require.NotNil(t, received)
+		assert.Nil(t, received.Data)
+		assert.JSONEq(t, `{"foo":"bar"}`, string(received.RawData))
+	})
+
+	t.Run("Deduplication", func(t *testing.T) {
+		t.Parallel()
+
+		fail := true
+		called := 0
+		h := NewHandler(token)
+		h.On(EventTypePagePropertiesUpdated, func(_ context.Context, _ *Event) error {
+			called++
+			if fail {
+				return errors.New("temporary failure")
+			}
+			return nil
+		})
+		body, err := os.ReadFile("./testdata/page-properties-updated.json")
+		require.NoError(t, err)
+
+		// The failed event is handled again by the retried delivery.
+		assert.Equal(t, http.StatusInternalServerError, send(h, body, SignatureValue(token, body)))
+		fail = false
+		assert.Equal(t, http.StatusOK, send(h, body, SignatureValue(token, body)))
+		assert.Equal(t, http.StatusOK, send(h, body, SignatureValue(token, body)))
+		assert.Equal(t, 2, called)
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	s := NewMemoryStore(2)
+	ctx := context.Background()
+	for _, v := range []string{"a", "b"} {
+		added, err := s.Add(ctx, v)
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+	added, err := s.Add(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	// "a" is evicted because the store remembers only 2 IDs.
+	added, err = s.Add(ctx, "c")
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = s.Add(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	require.NoError(t, s.Remove(ctx, "a"))
+	added, err = s.Add(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, added)
+}